@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+const (
+	cDefaultAddressTransactions = 50
+	cMaxAddressTransactions     = 500
+
+	cQueryCursor = "cursor"
+	cQueryLimit  = "limit"
+)
+
+func (api *Server) getAddressBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	address := r.PathValue("address")
+	if !legacy.IsValidHashAddress(address) {
+		http.Error(w, fmt.Sprintf("Invalid address '%s'", address), http.StatusBadRequest)
+		return
+	}
+
+	balance, err := api.backend.AddressBalance(address)
+	if err != nil {
+		writeLookupError(w, "balance", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, balance)
+}
+
+func (api *Server) getAddressTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	address := r.PathValue("address")
+	if !legacy.IsValidHashAddress(address) {
+		http.Error(w, fmt.Sprintf("Invalid address '%s'", address), http.StatusBadRequest)
+		return
+	}
+
+	values := r.URL.Query()
+	limit := cDefaultAddressTransactions
+	if value := values.Get(cQueryLimit); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > cMaxAddressTransactions {
+			http.Error(w, fmt.Sprintf("The limit must be between 1 and %d", cMaxAddressTransactions), http.StatusBadRequest)
+			return
+		}
+	}
+
+	transactions, err := api.backend.AddressTransactions(address, values.Get(cQueryCursor), limit)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeLookupError(w, "transactions", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, transactions)
+}
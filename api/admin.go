@@ -0,0 +1,157 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cConnectMaxBodySize = 4 * 1024
+)
+
+var (
+	// ErrInvalidPeerID is returned by the backend for IDs that aren't libp2p peer IDs
+	ErrInvalidPeerID = errors.New("invalid peer id")
+	// ErrInvalidPeerAddress is returned for addresses that aren't multiaddrs ending in a peer ID
+	ErrInvalidPeerAddress = errors.New("invalid peer address")
+	// ErrPeerBanned is returned when connecting to a banned peer
+	ErrPeerBanned = errors.New("peer is banned")
+	// ErrPeerUnreachable is returned when a peer could not be dialed
+	ErrPeerUnreachable = errors.New("peer unreachable")
+)
+
+func (api *Server) postShutdownHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log.Info("api shutdown requested")
+	w.WriteHeader(http.StatusAccepted)
+	api.backend.RequestShutdown()
+}
+
+func (api *Server) postRescanHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log.Info("api rescan requested")
+	if err := api.backend.Rescan(); err != nil {
+		log.Error("api could not rescan", err)
+		http.Error(w, "Could not rescan the blockchain", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *Server) getBansHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	peers, err := api.backend.BannedPeers()
+	if err != nil {
+		writeLookupError(w, "bans", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, &pb.DNSPeersResponse{Peers: peers, Total: uint32(len(peers))})
+}
+
+// Bans a peer on PUT, lifts the ban on DELETE
+func (api *Server) banHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var err error
+	switch r.Method {
+	case http.MethodPut:
+		log.Infof("api banning peer '%s'", id)
+		err = api.backend.BanPeer(id)
+	case http.MethodDelete:
+		log.Infof("api lifting ban of peer '%s'", id)
+		err = api.backend.UnbanPeer(id)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if errors.Is(err, ErrInvalidPeerID) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeLookupError(w, "peer", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Lists the peers on GET, connects to one on POST
+func (api *Server) peersHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		peers := api.backend.Peers()
+		pb.WriteNegotiated(w, r, &pb.DNSPeersResponse{Peers: peers, Total: uint32(len(peers))})
+	case http.MethodPost:
+		api.postPeerHandler(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *Server) postPeerHandler(w http.ResponseWriter, r *http.Request) {
+	request := &pb.APIConnectPeer{}
+	if err := pb.ReadMessage(w, r, cConnectMaxBodySize, request); err != nil {
+		if errors.Is(err, pb.ErrUnsupportedMediaType) {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		pb.WriteNegotiatedStatus(w, r, http.StatusBadRequest, &pb.APIError{Error: "could not decode the request"})
+		return
+	}
+
+	log.Infof("api connecting to peer '%s'", request.Address)
+	info, err := api.backend.ConnectPeer(request.Address)
+	switch {
+	case errors.Is(err, ErrInvalidPeerAddress):
+		pb.WriteNegotiatedStatus(w, r, http.StatusBadRequest, &pb.APIError{Error: err.Error(), Field: "address"})
+	case errors.Is(err, ErrPeerBanned):
+		pb.WriteNegotiatedStatus(w, r, http.StatusConflict, &pb.APIError{Error: err.Error()})
+	case errors.Is(err, ErrPeerUnreachable):
+		pb.WriteNegotiatedStatus(w, r, http.StatusBadGateway, &pb.APIError{Error: err.Error()})
+	case err != nil:
+		log.Error("api could not connect to peer", err)
+		pb.WriteNegotiatedStatus(w, r, http.StatusInternalServerError, &pb.APIError{Error: "could not connect to the peer"})
+	default:
+		pb.WriteNegotiated(w, r, info)
+	}
+}
+
+// Closes the connections to a peer, which may come back unless banned
+func (api *Server) deletePeerHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.PathValue("id")
+	log.Infof("api disconnecting peer '%s'", id)
+	err := api.backend.DisconnectPeer(id)
+	if errors.Is(err, ErrInvalidPeerID) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeLookupError(w, "connected peer", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
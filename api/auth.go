@@ -0,0 +1,159 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Role is what a client is allowed to do
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleRead
+	RoleAdmin
+)
+
+const (
+	// CookieUser is the user written in the cookie file, as bitcoind does
+	CookieUser = "__cookie__"
+
+	cRoleRead  = "read"
+	cRoleAdmin = "admin"
+
+	cBearerPrefix = "Bearer "
+	cBearerRealm  = `Bearer realm="nosogod"`
+)
+
+// Grants roles to the configured tokens and the cookie
+type auth struct {
+	mu          sync.RWMutex
+	tokens      map[[sha256.Size]byte]Role
+	publicReads bool
+	cookieFile  string
+}
+
+// Parses the `role:token` entries of the configuration
+func newAuth(tokens []string, publicReads bool) (*auth, error) {
+	a := &auth{
+		tokens:      make(map[[sha256.Size]byte]Role),
+		publicReads: publicReads,
+	}
+
+	// Entries are told by position, they hold secrets
+	for index, entry := range tokens {
+		name, token, ok := strings.Cut(entry, ":")
+		if !ok || token == "" {
+			return nil, fmt.Errorf("invalid api token %d, expected 'role:token'", index+1)
+		}
+		switch name {
+		case cRoleRead:
+			a.tokens[sha256.Sum256([]byte(token))] = RoleRead
+		case cRoleAdmin:
+			a.tokens[sha256.Sum256([]byte(token))] = RoleAdmin
+		default:
+			return nil, fmt.Errorf("invalid api token %d, unknown role", index+1)
+		}
+	}
+
+	return a, nil
+}
+
+// Tells the role of a token, looked up by hash so its content doesn't leak through timing
+func (a *auth) role(token string) Role {
+	if token == "" {
+		return RoleNone
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.tokens[sha256.Sum256([]byte(token))]
+}
+
+// Takes the token from a bearer header, or the password of basic auth
+func requestToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(header, cBearerPrefix); ok {
+		return strings.TrimSpace(token)
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+	return ""
+}
+
+// Requires a role, reads are let through when public
+func (a *auth) require(role Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if role == RoleRead && a.publicReads {
+			next(w, r)
+			return
+		}
+
+		granted := a.role(requestToken(r))
+		if granted == RoleNone {
+			w.Header().Set("WWW-Authenticate", cBearerRealm)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if granted < role {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// Writes a fresh admin token to the cookie file, readable only by the user
+func (a *auth) writeCookie(path string) error {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(secret)
+
+	if err := os.WriteFile(path, []byte(CookieUser+":"+token), 0o600); err != nil {
+		return fmt.Errorf("could not write cookie file: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tokens[sha256.Sum256([]byte(token))] = RoleAdmin
+	a.cookieFile = path
+
+	return nil
+}
+
+// Removes the cookie file, its token dies with the server
+func (a *auth) removeCookie() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cookieFile == "" {
+		return nil
+	}
+	err := os.Remove(a.cookieFile)
+	a.cookieFile = ""
+	return err
+}
+
+// ReadCookie returns the token in a cookie file written by the node
+func ReadCookie(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	user, token, ok := strings.Cut(strings.TrimSpace(string(data)), ":")
+	if !ok || user != CookieUser || token == "" {
+		return "", fmt.Errorf("invalid cookie file '%s'", path)
+	}
+	return token, nil
+}
@@ -1,5 +0,0 @@
-package api
-
-type BlocksStatus struct {
-	Height int64 `json:"height"`
-}
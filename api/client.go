@@ -0,0 +1,352 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cClientTimeout     = 10 * time.Second
+	cClientMaxBodySize = 4 * 1024 * 1024
+)
+
+// ErrNodeUnreachable is returned when the node can't be connected to
+var ErrNodeUnreachable = errors.New("node unreachable")
+
+// StatusError is an answer of the node other than a success
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("node answered %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("node answered %d: %s", e.StatusCode, e.Message)
+}
+
+// Client queries the API of a node
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	// Streams last as long as they're wanted, they get no timeout
+	streamClient *http.Client
+}
+
+// NewClient creates a client for the node API at baseURL, sending token when not empty.
+// A nil tlsConfig uses the system defaults.
+func NewClient(baseURL string, token string, tlsConfig *tls.Config) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid api url '%s'", baseURL)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &Client{
+		baseURL:      u.Scheme + "://" + u.Host,
+		token:        token,
+		httpClient:   &http.Client{Timeout: cClientTimeout, Transport: transport},
+		streamClient: &http.Client{Transport: transport},
+	}, nil
+}
+
+// BlocksStatus returns the height and last hash of the node's chain
+func (c *Client) BlocksStatus(ctx context.Context) (*pb.APIBlocksStatus, error) {
+	status := &pb.APIBlocksStatus{}
+	if err := c.getMessage(ctx, APIBlocksStatus, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// NetworkStatus returns the mode and peer counts of the node
+func (c *Client) NetworkStatus(ctx context.Context) (*pb.APINetworkStatus, error) {
+	status := &pb.APINetworkStatus{}
+	if err := c.getMessage(ctx, APINetworkStatus, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Block returns the block at a height
+func (c *Client) Block(ctx context.Context, height uint64) (*pb.Block, error) {
+	block := &pb.Block{}
+	if err := c.getMessage(ctx, "blocks/"+strconv.FormatUint(height, 10), block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// BlockByHash returns the block with a hash
+func (c *Client) BlockByHash(ctx context.Context, hash string) (*pb.Block, error) {
+	block := &pb.Block{}
+	if err := c.getMessage(ctx, "blocks/hash/"+url.PathEscape(hash), block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// Blocks returns the blocks between two heights, both included, at most a page of them
+func (c *Client) Blocks(ctx context.Context, from uint64, to uint64) ([]*pb.Block, error) {
+	query := url.Values{}
+	query.Set(cQueryFrom, strconv.FormatUint(from, 10))
+	query.Set(cQueryTo, strconv.FormatUint(to, 10))
+
+	blocks := &pb.APIBlocks{}
+	if err := c.getMessage(ctx, APIBlocks+"?"+query.Encode(), blocks); err != nil {
+		return nil, err
+	}
+	return blocks.Blocks, nil
+}
+
+// AddressBalance returns the balance of an address
+func (c *Client) AddressBalance(ctx context.Context, address string) (*pb.APIAddressBalance, error) {
+	balance := &pb.APIAddressBalance{}
+	if err := c.getMessage(ctx, "addresses/"+url.PathEscape(address)+"/balance", balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// SubmitTransaction hands a signed transaction to the node, returning its hash
+func (c *Client) SubmitTransaction(ctx context.Context, transaction *pb.Transaction) (string, error) {
+	submitted := &pb.APITransactionSubmitted{}
+	if err := c.send(ctx, http.MethodPost, APITransactions, transaction, submitted); err != nil {
+		return "", err
+	}
+	return submitted.Hash, nil
+}
+
+// Peers returns the peers the node knows of and is connected to
+func (c *Client) Peers(ctx context.Context) ([]*pb.PeerInfo, error) {
+	peers := &pb.DNSPeersResponse{}
+	if err := c.getMessage(ctx, APIPeers, peers); err != nil {
+		return nil, err
+	}
+	return peers.Peers, nil
+}
+
+// ConnectPeer has the node dial a peer at a multiaddr ending in its ID
+func (c *Client) ConnectPeer(ctx context.Context, address string) (*pb.PeerInfo, error) {
+	info := &pb.PeerInfo{}
+	if err := c.send(ctx, http.MethodPost, APIPeers, &pb.APIConnectPeer{Address: address}, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DisconnectPeer has the node close its connections to a peer
+func (c *Client) DisconnectPeer(ctx context.Context, id string) error {
+	return c.send(ctx, http.MethodDelete, APIPeers+"/"+url.PathEscape(id), nil, nil)
+}
+
+// BanPeer has the node drop a peer and refuse it from now on
+func (c *Client) BanPeer(ctx context.Context, id string) error {
+	return c.send(ctx, http.MethodPut, APIBans+"/"+url.PathEscape(id), nil, nil)
+}
+
+// UnbanPeer lifts the ban of a peer
+func (c *Client) UnbanPeer(ctx context.Context, id string) error {
+	return c.send(ctx, http.MethodDelete, APIBans+"/"+url.PathEscape(id), nil, nil)
+}
+
+// BannedPeers returns the peers banned by the node
+func (c *Client) BannedPeers(ctx context.Context) ([]*pb.PeerInfo, error) {
+	peers := &pb.DNSPeersResponse{}
+	if err := c.getMessage(ctx, APIBans, peers); err != nil {
+		return nil, err
+	}
+	return peers.Peers, nil
+}
+
+// Events follows the event stream, handing each event to handle until the
+// context ends, handle fails or the node closes the stream. Blocks from a
+// height, when given, are sent first.
+func (c *Client) Events(ctx context.Context, from *uint64, handle func(*pb.APIEvent) error) error {
+	endpoint := APIEvents
+	if from != nil {
+		endpoint += "?" + cQueryFrom + "=" + strconv.FormatUint(*from, 10)
+	}
+
+	resp, err := c.do(ctx, c.streamClient, http.MethodGet, endpoint, "text/event-stream", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), cClientMaxBodySize)
+	var name, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// A blank line ends the event
+			if name != "" {
+				event, err := decodeEvent(name, data)
+				if err != nil {
+					return err
+				}
+				if err := handle(event); err != nil {
+					return err
+				}
+			}
+			name, data = "", ""
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+// Builds back the event from its Server-Sent Events name and JSON data
+func decodeEvent(name string, data string) (*pb.APIEvent, error) {
+	var (
+		event   = &pb.APIEvent{}
+		payload proto.Message
+	)
+	switch name {
+	case cEventBlock:
+		block := &pb.APIEventBlock{}
+		event.Payload, payload = &pb.APIEvent_Block{Block: block}, block
+	case cEventReorg:
+		reorg := &pb.APIEventReorg{}
+		event.Payload, payload = &pb.APIEvent_Reorg{Reorg: reorg}, reorg
+	case cEventTransaction:
+		transaction := &pb.Transaction{}
+		event.Payload, payload = &pb.APIEvent_Transaction{Transaction: transaction}, transaction
+	default:
+		return nil, fmt.Errorf("unknown event '%s'", name)
+	}
+
+	if err := pb.UnmarshalJSON([]byte(data), payload); err != nil {
+		return nil, fmt.Errorf("could not decode %s event: %w", name, err)
+	}
+	return event, nil
+}
+
+// Decodes the protobuf answer of an endpoint into msg
+func (c *Client) getMessage(ctx context.Context, endpoint string, msg proto.Message) error {
+	data, err := c.read(ctx, endpoint, pb.ContentTypeProtoBuf)
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("could not decode answer: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) read(ctx context.Context, endpoint string, accept string) ([]byte, error) {
+	resp, err := c.do(ctx, c.httpClient, http.MethodGet, endpoint, accept, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(io.LimitReader(resp.Body, cClientMaxBodySize))
+}
+
+// Sends a request with the protobuf body, if any, in the answer to msg, if any
+func (c *Client) send(ctx context.Context, method string, endpoint string, body proto.Message, msg proto.Message) error {
+	resp, err := c.do(ctx, c.httpClient, method, endpoint, pb.ContentTypeProtoBuf, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, cClientMaxBodySize))
+	if err != nil || msg == nil {
+		return err
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("could not decode answer: %w", err)
+	}
+	return nil
+}
+
+// Sends a request, answers other than a success are turned into a StatusError
+func (c *Client) do(
+	ctx context.Context,
+	httpClient *http.Client,
+	method string,
+	endpoint string,
+	accept string,
+	body proto.Message,
+) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := proto.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+Route(endpoint), reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if body != nil {
+		req.Header.Set("Content-Type", pb.ContentTypeProtoBuf)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", cBearerPrefix+c.token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNodeUnreachable, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, cClientMaxBodySize))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Message: errorMessage(resp, data)}
+	}
+	return resp, nil
+}
+
+// Takes the message out of an APIError answer, or the plain text one of http.Error
+func errorMessage(resp *http.Response, data []byte) string {
+	apiErr := &pb.APIError{}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case pb.ContentTypeProtoBuf:
+		if proto.Unmarshal(data, apiErr) == nil && apiErr.Error != "" {
+			return apiErr.Error
+		}
+	case pb.ContentTypeJSON:
+		if pb.UnmarshalJSON(data, apiErr) == nil && apiErr.Error != "" {
+			return apiErr.Error
+		}
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -5,5 +5,37 @@ const (
 
 	APIBlocksStatus = "blocks/status"
 
+	APIBlocks = "blocks"
+
+	APIBlock = "blocks/{height}"
+
+	APIBlockByHash = "blocks/hash/{hash}"
+
+	APIBlockTransactions = "blocks/{height}/transactions"
+
+	APITransactions = "transactions"
+
+	APITransaction = "transactions/{hash}"
+
+	APIAddressBalance = "addresses/{address}/balance"
+
+	APIAddressTransactions = "addresses/{address}/transactions"
+
 	APINetworkStatus = "network/status"
+
+	APIEvents = "events"
+
+	APIRescan = "blocks/rescan"
+
+	APIShutdown = "node/shutdown"
+
+	APIPeers = "network/peers"
+
+	APIPeer = "network/peers/{id}"
+
+	APIBans = "network/bans"
+
+	APIBan = "network/bans/{id}"
+
+	NetworkMainnet = "mainnet"
 )
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cEventsBuffer    = 256
+	cEventsKeepAlive = 15 * time.Second
+
+	cQueryAddress = "address"
+
+	cEventBlock       = "block"
+	cEventReorg       = "reorg"
+	cEventTransaction = "transaction"
+)
+
+var errEventsClosed = errors.New("events closed")
+
+// Events fans out node events to the streaming clients
+type Events struct {
+	mu          sync.Mutex
+	subscribers map[chan *pb.APIEvent]struct{}
+	closed      bool
+}
+
+func NewEvents() *Events {
+	return &Events{
+		subscribers: make(map[chan *pb.APIEvent]struct{}),
+	}
+}
+
+// Publish hands an event to every subscriber. Subscribers too slow to keep up
+// are dropped, they can resume from the last block they got.
+func (e *Events) Publish(event *pb.APIEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for events := range e.subscribers {
+		select {
+		case events <- event:
+		default:
+			log.Warn("api events subscriber too slow, dropping it")
+			delete(e.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// Subscribe returns a channel of events, closed on unsubscribing or when falling behind
+func (e *Events) Subscribe() (chan *pb.APIEvent, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	events := make(chan *pb.APIEvent, cEventsBuffer)
+	if e.closed {
+		close(events)
+		return events, func() {}
+	}
+	e.subscribers[events] = struct{}{}
+
+	return events, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if _, ok := e.subscribers[events]; ok {
+			delete(e.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// Close ends every subscription, and any later one
+func (e *Events) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.closed = true
+	for events := range e.subscribers {
+		delete(e.subscribers, events)
+		close(events)
+	}
+}
+
+// Streams events as Server-Sent Events. Block events carry their height as id,
+// so clients resume with `Last-Event-ID` or `?from=` and get the missed blocks first.
+func (api *Server) getEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := r.URL.Query()
+	address := values.Get(cQueryAddress)
+	if address != "" && !legacy.IsValidHashAddress(address) {
+		http.Error(w, fmt.Sprintf("Invalid address '%s'", address), http.StatusBadRequest)
+		return
+	}
+
+	var from *uint64
+	if value := r.Header.Get("Last-Event-ID"); value != "" {
+		lastHeight, err := parseHeight(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		from = proto.Uint64(lastHeight + 1)
+	} else if value := values.Get(cQueryFrom); value != "" {
+		height, err := parseHeight(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		from = &height
+	}
+
+	// A stream outlives the server write timeout, when the writer lets us
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	send := func(event *pb.APIEvent) error {
+		if err := writeEvent(w, event); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	keepAlive := func() error {
+		if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := api.streamEvents(r.Context(), from, address, send, keepAlive); err != nil {
+		log.Debugf("api events stream ended: %v", err)
+	}
+}
+
+// Sends the stored blocks from a height, when given, and then the live events
+// until the context or the server ends. Keeps the stream alive when idle if asked to.
+func (api *Server) streamEvents(
+	ctx context.Context,
+	from *uint64,
+	address string,
+	send func(*pb.APIEvent) error,
+	keepAlive func() error,
+) error {
+	// Subscribe before replaying, so nothing falls in between
+	events, unsubscribe := api.events.Subscribe()
+	defer unsubscribe()
+
+	// Blocks below the next height were already sent by the replay
+	var next uint64
+	if from != nil {
+		var err error
+		if next, err = api.replayBlocks(*from, send); err != nil {
+			return err
+		}
+	}
+
+	var tick <-chan time.Time
+	if keepAlive != nil {
+		ticker := time.NewTicker(cEventsKeepAlive)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-api.ctx.Done():
+			return api.ctx.Err()
+		case <-tick:
+			if err := keepAlive(); err != nil {
+				return err
+			}
+		case event, ok := <-events:
+			if !ok {
+				return errEventsClosed
+			}
+			switch payload := event.Payload.(type) {
+			case *pb.APIEvent_Block:
+				if payload.Block.Block.Height < next {
+					continue
+				}
+			case *pb.APIEvent_Reorg:
+				// The replacing block has to go out even if replayed
+				next = min(next, payload.Reorg.Height)
+			case *pb.APIEvent_Transaction:
+				if address != "" && payload.Transaction.Sender != address && payload.Transaction.Receiver != address {
+					continue
+				}
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Sends the stored blocks from a height on, returning the height after the last one sent
+func (api *Server) replayBlocks(from uint64, send func(*pb.APIEvent) error) (uint64, error) {
+	status, err := api.backend.BlocksStatus()
+	if err != nil {
+		return 0, err
+	}
+
+	next := from
+	for start := from; start <= status.Height; start += cMaxBlocksRange {
+		end := min(start+cMaxBlocksRange-1, status.Height)
+		blocks, err := api.backend.Blocks(start, end)
+		if err != nil {
+			return 0, err
+		}
+		for _, block := range blocks {
+			transactions, err := api.backend.BlockTransactions(block.Height)
+			if err != nil {
+				return 0, err
+			}
+			event := &pb.APIEvent{
+				Payload: &pb.APIEvent_Block{
+					Block: &pb.APIEventBlock{Block: block, Transactions: transactions},
+				},
+			}
+			if err := send(event); err != nil {
+				return 0, err
+			}
+			next = block.Height + 1
+		}
+	}
+
+	return next, nil
+}
+
+// Writes an event in the Server-Sent Events format, the event name tells the JSON data
+func writeEvent(w http.ResponseWriter, event *pb.APIEvent) error {
+	var (
+		name, id string
+		payload  proto.Message
+	)
+	switch event := event.Payload.(type) {
+	case *pb.APIEvent_Block:
+		name, id = cEventBlock, strconv.FormatUint(event.Block.Block.Height, 10)
+		payload = event.Block
+	case *pb.APIEvent_Reorg:
+		name, payload = cEventReorg, event.Reorg
+	case *pb.APIEvent_Transaction:
+		name, payload = cEventTransaction, event.Transaction
+	}
+
+	data, err := pb.MarshalJSON(payload)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
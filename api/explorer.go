@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+const (
+	cMaxBlocksRange = 100
+
+	cQueryFrom  = "from"
+	cQueryTo    = "to"
+	cQueryBlock = "block"
+
+	// Route of APIBlockTransactions, see NewServer
+	cBlockSubPath = "blocks/{height}/{sub}"
+)
+
+func (api *Server) getBlockHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	height, err := parseHeight(r.PathValue("height"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	block, err := api.backend.Block(height)
+	if err != nil {
+		writeLookupError(w, "block", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, block)
+}
+
+func (api *Server) getBlockByHashHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	block, err := api.backend.BlockByHash(r.PathValue("hash"))
+	if err != nil {
+		writeLookupError(w, "block", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, block)
+}
+
+func (api *Server) getBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := r.URL.Query()
+	from, err := parseHeight(values.Get(cQueryFrom))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to := from + cMaxBlocksRange - 1
+	if value := values.Get(cQueryTo); value != "" {
+		if to, err = parseHeight(value); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if to < from || to-from >= cMaxBlocksRange {
+		http.Error(w, fmt.Sprintf("The range must hold between 1 and %d blocks", cMaxBlocksRange), http.StatusBadRequest)
+		return
+	}
+
+	blocks, err := api.backend.Blocks(from, to)
+	if err != nil {
+		writeLookupError(w, "blocks", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, &pb.APIBlocks{Blocks: blocks})
+}
+
+// Lists the transactions of the block given with `?block=`
+func (api *Server) getTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	value := r.URL.Query().Get(cQueryBlock)
+	if value == "" {
+		http.Error(w, "The block is missing", http.StatusBadRequest)
+		return
+	}
+	height, err := parseHeight(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	api.writeBlockTransactions(w, r, height)
+}
+
+func (api *Server) getBlockTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("sub") != path.Base(APIBlockTransactions) {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	height, err := parseHeight(r.PathValue("height"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	api.writeBlockTransactions(w, r, height)
+}
+
+func (api *Server) writeBlockTransactions(w http.ResponseWriter, r *http.Request, height uint64) {
+	transactions, err := api.backend.BlockTransactions(height)
+	if err != nil {
+		writeLookupError(w, "transactions", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, &pb.APITransactions{Transactions: transactions})
+}
+
+func (api *Server) getTransactionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	transaction, err := api.backend.Transaction(r.PathValue("hash"))
+	if err != nil {
+		writeLookupError(w, "transaction", err)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, transaction)
+}
+
+// An empty height is block zero
+func parseHeight(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	height, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid height '%s'", value)
+	}
+	return height, nil
+}
+
+func writeLookupError(w http.ResponseWriter, what string, err error) {
+	if errors.Is(err, store.ErrNotFound) {
+		http.Error(w, fmt.Sprintf("No %s found", what), http.StatusNotFound)
+		return
+	}
+	log.Errorf("api could not get %s", err, what)
+	http.Error(w, fmt.Sprintf("Could not get %s", what), http.StatusInternalServerError)
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+// GRPCServer serves the REST API queries and the event stream over gRPC
+type GRPCServer struct {
+	pb.UnimplementedAPIServer
+
+	api         *Server
+	server      *grpc.Server
+	grpcAddress string
+}
+
+// NewGRPCServer serves the backend and events of the API server on another address
+func NewGRPCServer(api *Server, address string, config *cfg.APIConfig) (*GRPCServer, error) {
+	tlsConfig, err := utils.NewTLSConfig(config.TLSCertFile, config.TLSKeyFile, config.TLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := api.auth.authorizeGRPC(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := api.auth.authorizeGRPC(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := &GRPCServer{
+		api:         api,
+		server:      grpc.NewServer(options...),
+		grpcAddress: address,
+	}
+	pb.RegisterAPIServer(grpcServer.server, grpcServer)
+
+	return grpcServer, nil
+}
+
+// Starts the gRPC server
+func (g *GRPCServer) Start() {
+	defer g.api.wg.Done()
+
+	listener, err := net.Listen("tcp", g.grpcAddress)
+	if err != nil {
+		log.Error("grpc Listen", err)
+		g.api.shutdown()
+		return
+	}
+
+	log.Infof("grpc server: Listening on %s", g.grpcAddress)
+	if err := g.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		log.Error("grpc Serve", err)
+		g.api.shutdown()
+	}
+}
+
+// Shuts down the gRPC server
+func (g *GRPCServer) ShutDown() {
+	log.Info("grpc server shuting down")
+	// Streams don't end on their own
+	g.api.events.Close()
+	g.server.GracefulStop()
+}
+
+// Serve serves on a listener given by the caller
+func (g *GRPCServer) Serve(listener net.Listener) error {
+	return g.server.Serve(listener)
+}
+
+func (g *GRPCServer) GetBlocksStatus(ctx context.Context, _ *emptypb.Empty) (*pb.APIBlocksStatus, error) {
+	blocksStatus, err := g.api.backend.BlocksStatus()
+	if err != nil {
+		return nil, grpcError("blocks status", err)
+	}
+	return blocksStatus, nil
+}
+
+func (g *GRPCServer) GetNetworkStatus(ctx context.Context, _ *emptypb.Empty) (*pb.APINetworkStatus, error) {
+	return g.api.backend.NetworkStatus(), nil
+}
+
+func (g *GRPCServer) GetBlock(ctx context.Context, req *pb.APIHeightRequest) (*pb.Block, error) {
+	block, err := g.api.backend.Block(req.Height)
+	if err != nil {
+		return nil, grpcError("block", err)
+	}
+	return block, nil
+}
+
+func (g *GRPCServer) GetBlockByHash(ctx context.Context, req *pb.APIHashRequest) (*pb.Block, error) {
+	block, err := g.api.backend.BlockByHash(req.Hash)
+	if err != nil {
+		return nil, grpcError("block", err)
+	}
+	return block, nil
+}
+
+func (g *GRPCServer) GetBlocks(ctx context.Context, req *pb.APIBlocksRequest) (*pb.APIBlocks, error) {
+	to := req.To
+	if to == 0 {
+		to = req.From + cMaxBlocksRange - 1
+	}
+	if to < req.From || to-req.From >= cMaxBlocksRange {
+		return nil, status.Errorf(codes.InvalidArgument, "the range must hold between 1 and %d blocks", cMaxBlocksRange)
+	}
+
+	blocks, err := g.api.backend.Blocks(req.From, to)
+	if err != nil {
+		return nil, grpcError("blocks", err)
+	}
+	return &pb.APIBlocks{Blocks: blocks}, nil
+}
+
+func (g *GRPCServer) GetBlockTransactions(ctx context.Context, req *pb.APIHeightRequest) (*pb.APITransactions, error) {
+	transactions, err := g.api.backend.BlockTransactions(req.Height)
+	if err != nil {
+		return nil, grpcError("transactions", err)
+	}
+	return &pb.APITransactions{Transactions: transactions}, nil
+}
+
+func (g *GRPCServer) GetTransaction(ctx context.Context, req *pb.APIHashRequest) (*pb.Transaction, error) {
+	transaction, err := g.api.backend.Transaction(req.Hash)
+	if err != nil {
+		return nil, grpcError("transaction", err)
+	}
+	return transaction, nil
+}
+
+func (g *GRPCServer) GetAddressBalance(ctx context.Context, req *pb.APIAddressRequest) (*pb.APIAddressBalance, error) {
+	if !legacy.IsValidHashAddress(req.Address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address '%s'", req.Address)
+	}
+
+	balance, err := g.api.backend.AddressBalance(req.Address)
+	if err != nil {
+		return nil, grpcError("balance", err)
+	}
+	return balance, nil
+}
+
+func (g *GRPCServer) GetAddressTransactions(ctx context.Context, req *pb.APIAddressTransactionsRequest) (*pb.APIAddressTransactions, error) {
+	if !legacy.IsValidHashAddress(req.Address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address '%s'", req.Address)
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = cDefaultAddressTransactions
+	}
+	if limit > cMaxAddressTransactions {
+		return nil, status.Errorf(codes.InvalidArgument, "the limit must be between 1 and %d", cMaxAddressTransactions)
+	}
+
+	transactions, err := g.api.backend.AddressTransactions(req.Address, req.Cursor, limit)
+	if err != nil {
+		return nil, grpcError("transactions", err)
+	}
+	return transactions, nil
+}
+
+func (g *GRPCServer) SubmitTransaction(ctx context.Context, transaction *pb.Transaction) (*pb.APITransactionSubmitted, error) {
+	hash, err := g.api.backend.SubmitTransaction(transaction)
+	if err != nil {
+		return nil, grpcError("transaction", err)
+	}
+	return &pb.APITransactionSubmitted{Hash: hash}, nil
+}
+
+func (g *GRPCServer) SubscribeEvents(req *pb.APIEventsRequest, stream grpc.ServerStreamingServer[pb.APIEvent]) error {
+	if req.Address != "" && !legacy.IsValidHashAddress(req.Address) {
+		return status.Errorf(codes.InvalidArgument, "invalid address '%s'", req.Address)
+	}
+
+	err := g.api.streamEvents(stream.Context(), req.From, req.Address, stream.Send, nil)
+	if errors.Is(err, errEventsClosed) {
+		return status.Error(codes.Unavailable, "the events stream closed, resume from the last block")
+	}
+	if err != nil && stream.Context().Err() == nil {
+		return grpcError("events", err)
+	}
+	return nil
+}
+
+// Maps the backend errors to gRPC status codes, like the REST handlers do with HTTP ones
+func grpcError(what string, err error) error {
+	var transactionErr *pb.TransactionError
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return status.Errorf(codes.NotFound, "no %s found", what)
+	case errors.Is(err, store.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &transactionErr):
+		return status.Error(codes.InvalidArgument, transactionErr.Error())
+	case errors.Is(err, pb.ErrTransactionKnown):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	log.Errorf("grpc could not get %s", err, what)
+	return status.Error(codes.Internal, fmt.Sprintf("could not get %s", what))
+}
+
+// Every gRPC method is a read, it needs a token unless reads are public
+func (a *auth) authorizeGRPC(ctx context.Context) error {
+	if a.publicReads {
+		return nil
+	}
+
+	var token string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		token, _ = strings.CutPrefix(values[0], cBearerPrefix)
+	}
+	if a.role(token) == RoleNone {
+		return status.Error(codes.Unauthenticated, "a token is required")
+	}
+	return nil
+}
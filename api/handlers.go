@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+func (api *Server) getBlocksStatusHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("api serving blocks status")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status, err := api.backend.BlocksStatus()
+	if err != nil {
+		log.Error("api could not get blocks status", err)
+		http.Error(w, "Could not get blocks status", http.StatusInternalServerError)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, status)
+}
+
+func (api *Server) getNetworkStatusHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("api serving network status")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, api.backend.NetworkStatus())
+}
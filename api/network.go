@@ -1,5 +0,0 @@
-package api
-
-type NetworkStatus struct {
-	Network string `json:"network"`
-}
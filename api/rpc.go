@@ -0,0 +1,432 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+const (
+	cRPCMaxBodySize = 1024 * 1024
+	cRPCRealm       = `Basic realm="nosogod"`
+)
+
+// JSON-RPC 2.0 error codes, and the bitcoind ones exchanges look for
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+
+	rpcInvalidAddressOrKey  = -5
+	rpcDeserializationError = -22
+	rpcVerifyRejected       = -26
+	rpcVerifyAlreadyInChain = -27
+)
+
+type (
+	rpcRequest struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+		ID      json.RawMessage `json:"id"`
+	}
+
+	rpcResponse struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *rpcError       `json:"error,omitempty"`
+		ID      json.RawMessage `json:"id"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	rpcMethod func(api *Server, params []json.RawMessage) (any, *rpcError)
+
+	rpcBlock struct {
+		Hash              string   `json:"hash"`
+		Height            uint64   `json:"height"`
+		PreviousBlockHash string   `json:"previousblockhash"`
+		Time              int64    `json:"time"`
+		MerkleRoot        string   `json:"merkleroot"`
+		Confirmations     uint64   `json:"confirmations"`
+		Transactions      []string `json:"tx"`
+	}
+
+	rpcTransaction struct {
+		*pb.Transaction
+		Confirmations uint64 `json:"confirmations"`
+	}
+
+	rpcPeer struct {
+		ID        string  `json:"id"`
+		Address   string  `json:"addr"`
+		Mode      string  `json:"mode"`
+		Inbound   bool    `json:"inbound"`
+		LastRecv  int64   `json:"lastrecv"`
+		PingTime  float64 `json:"pingtime"`
+		Connected bool    `json:"connected"`
+	}
+
+	rpcAddressValidation struct {
+		IsValid bool   `json:"isvalid"`
+		Address string `json:"address,omitempty"`
+	}
+)
+
+var rpcMethods = map[string]rpcMethod{
+	"getblockcount":      rpcGetBlockCount,
+	"getbestblockhash":   rpcGetBestBlockHash,
+	"getblockhash":       rpcGetBlockHash,
+	"getblock":           rpcGetBlock,
+	"gettransaction":     rpcGetTransaction,
+	"getbalance":         rpcGetBalance,
+	"sendrawtransaction": rpcSendRawTransaction,
+	"getpeerinfo":        rpcGetPeerInfo,
+	"validateaddress":    rpcValidateAddress,
+}
+
+// Answers JSON-RPC 2.0 calls, single or batched, behind basic auth
+func (api *Server) rpcHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !api.rpcAuthorized(r) {
+		w.Header().Set("WWW-Authenticate", cRPCRealm)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cRPCMaxBodySize))
+	if err != nil {
+		http.Error(w, "Request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var requests []json.RawMessage
+		if err := json.Unmarshal(body, &requests); err != nil {
+			writeRPC(w, rpcFailure(nil, rpcParseError, "parse error"))
+			return
+		}
+		if len(requests) == 0 {
+			writeRPC(w, rpcFailure(nil, rpcInvalidRequest, "empty batch"))
+			return
+		}
+
+		responses := []*rpcResponse{}
+		for _, request := range requests {
+			if response := api.rpcCall(request); response != nil {
+				responses = append(responses, response)
+			}
+		}
+		// A batch of notifications gets nothing back
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeRPC(w, responses)
+		return
+	}
+
+	response := api.rpcCall(body)
+	if response == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeRPC(w, response)
+}
+
+// Runs one call, notifications without an id get no response
+func (api *Server) rpcCall(data []byte) *rpcResponse {
+	request := &rpcRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return rpcFailure(nil, rpcParseError, "parse error")
+		}
+		return rpcFailure(nil, rpcInvalidRequest, "invalid request")
+	}
+	// bitcoind clients still send "1.0", or nothing at all
+	if request.Method == "" || (request.JSONRPC != "" && request.JSONRPC != "2.0" && request.JSONRPC != "1.0") {
+		return rpcFailure(request.ID, rpcInvalidRequest, "invalid request")
+	}
+
+	method, ok := rpcMethods[request.Method]
+	if !ok && request.ID == nil {
+		return nil
+	}
+	if !ok {
+		return rpcFailure(request.ID, rpcMethodNotFound, fmt.Sprintf("method '%s' not found", request.Method))
+	}
+
+	// Positional params only, like bitcoind clients send them
+	var params []json.RawMessage
+	if len(request.Params) > 0 && string(request.Params) != "null" {
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return rpcFailure(request.ID, rpcInvalidParams, "params must be an array")
+		}
+	}
+
+	result, rpcErr := method(api, params)
+	if request.ID == nil {
+		return nil
+	}
+	if rpcErr != nil {
+		return &rpcResponse{JSONRPC: "2.0", Error: rpcErr, ID: request.ID}
+	}
+
+	// Marshaled here, a zero result still has to be there
+	data, err := json.Marshal(result)
+	if err != nil {
+		log.Error("rpc could not marshal result", err)
+		return rpcFailure(request.ID, rpcInternalError, "could not marshal the result")
+	}
+	return &rpcResponse{JSONRPC: "2.0", Result: data, ID: request.ID}
+}
+
+// Compares hashes so neither the length nor the content leak through timing
+func (api *Server) rpcAuthorized(r *http.Request) bool {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	userHash := sha256.Sum256([]byte(user))
+	wantUserHash := sha256.Sum256([]byte(api.rpcUser))
+	passwordHash := sha256.Sum256([]byte(password))
+	wantPasswordHash := sha256.Sum256([]byte(api.rpcPassword))
+
+	userMatch := subtle.ConstantTimeCompare(userHash[:], wantUserHash[:])
+	passwordMatch := subtle.ConstantTimeCompare(passwordHash[:], wantPasswordHash[:])
+	return userMatch&passwordMatch == 1
+}
+
+func rpcGetBlockCount(api *Server, params []json.RawMessage) (any, *rpcError) {
+	status, err := api.backend.BlocksStatus()
+	if err != nil {
+		return nil, rpcBackendError("block count", err)
+	}
+	return status.Height, nil
+}
+
+func rpcGetBestBlockHash(api *Server, params []json.RawMessage) (any, *rpcError) {
+	status, err := api.backend.BlocksStatus()
+	if err != nil {
+		return nil, rpcBackendError("best block", err)
+	}
+	return status.LastHash, nil
+}
+
+func rpcGetBlockHash(api *Server, params []json.RawMessage) (any, *rpcError) {
+	var height uint64
+	if rpcErr := rpcParams(params, 1, &height); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	block, err := api.backend.Block(height)
+	if err != nil {
+		return nil, rpcBackendError("block", err)
+	}
+	return block.Hash, nil
+}
+
+func rpcGetBlock(api *Server, params []json.RawMessage) (any, *rpcError) {
+	var hash string
+	if rpcErr := rpcParams(params, 1, &hash); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	block, err := api.backend.BlockByHash(hash)
+	if err != nil {
+		return nil, rpcBackendError("block", err)
+	}
+	transactions, err := api.backend.BlockTransactions(block.Height)
+	if err != nil {
+		return nil, rpcBackendError("transactions", err)
+	}
+	confirmations, rpcErr := api.rpcConfirmations(block.Height)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	result := &rpcBlock{
+		Hash:              block.Hash,
+		Height:            block.Height,
+		PreviousBlockHash: block.PreviousHash,
+		Time:              block.Timestamp,
+		MerkleRoot:        block.MerkleRoot,
+		Confirmations:     confirmations,
+		Transactions:      []string{},
+	}
+	for _, transaction := range transactions {
+		result.Transactions = append(result.Transactions, transaction.Hash)
+	}
+	return result, nil
+}
+
+func rpcGetTransaction(api *Server, params []json.RawMessage) (any, *rpcError) {
+	var hash string
+	if rpcErr := rpcParams(params, 1, &hash); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	transaction, err := api.backend.Transaction(hash)
+	if err != nil {
+		return nil, rpcBackendError("transaction", err)
+	}
+	confirmations, rpcErr := api.rpcConfirmations(transaction.BlockHeight)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return &rpcTransaction{Transaction: transaction, Confirmations: confirmations}, nil
+}
+
+// The balance is in coins, the node holds no wallet so the address is required
+func rpcGetBalance(api *Server, params []json.RawMessage) (any, *rpcError) {
+	var address string
+	if rpcErr := rpcParams(params, 1, &address); rpcErr != nil {
+		return nil, rpcErr
+	}
+	if !legacy.IsValidHashAddress(address) {
+		return nil, &rpcError{rpcInvalidAddressOrKey, fmt.Sprintf("invalid address '%s'", address)}
+	}
+
+	balance, err := api.backend.AddressBalance(address)
+	if err != nil {
+		return nil, rpcBackendError("balance", err)
+	}
+	return formatCoins(balance.Balance), nil
+}
+
+// Takes a hex encoded protobuf transaction, signed
+func rpcSendRawTransaction(api *Server, params []json.RawMessage) (any, *rpcError) {
+	var raw string
+	if rpcErr := rpcParams(params, 1, &raw); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	data, err := hex.DecodeString(raw)
+	if err != nil {
+		return nil, &rpcError{rpcDeserializationError, "transaction is not hex encoded"}
+	}
+	transaction := &pb.Transaction{}
+	if err := proto.Unmarshal(data, transaction); err != nil {
+		return nil, &rpcError{rpcDeserializationError, "transaction decode failed"}
+	}
+
+	hash, err := api.backend.SubmitTransaction(transaction)
+	var transactionErr *pb.TransactionError
+	switch {
+	case errors.As(err, &transactionErr):
+		return nil, &rpcError{rpcVerifyRejected, transactionErr.Error()}
+	case errors.Is(err, pb.ErrTransactionKnown):
+		return nil, &rpcError{rpcVerifyAlreadyInChain, err.Error()}
+	case err != nil:
+		return nil, rpcBackendError("transaction", err)
+	}
+	return hash, nil
+}
+
+func rpcGetPeerInfo(api *Server, params []json.RawMessage) (any, *rpcError) {
+	peers := []*rpcPeer{}
+	for _, peer := range api.backend.Peers() {
+		peers = append(peers, &rpcPeer{
+			ID:        peer.Id,
+			Address:   fmt.Sprintf("%s:%d", peer.Address, peer.Port),
+			Mode:      peer.Mode,
+			Inbound:   peer.Direction == pb.DirectionInbound,
+			LastRecv:  peer.LastSeen,
+			PingTime:  float64(peer.LatencyMs) / 1000,
+			Connected: peer.Connected,
+		})
+	}
+	return peers, nil
+}
+
+func rpcValidateAddress(api *Server, params []json.RawMessage) (any, *rpcError) {
+	var address string
+	if rpcErr := rpcParams(params, 1, &address); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if !legacy.IsValidHashAddress(address) {
+		return &rpcAddressValidation{IsValid: false}, nil
+	}
+	return &rpcAddressValidation{IsValid: true, Address: address}, nil
+}
+
+// Blocks on top of the height, counting its own
+func (api *Server) rpcConfirmations(height uint64) (uint64, *rpcError) {
+	status, err := api.backend.BlocksStatus()
+	if err != nil {
+		return 0, rpcBackendError("block count", err)
+	}
+	if height > status.Height {
+		return 0, nil
+	}
+	return status.Height - height + 1, nil
+}
+
+// Decodes the first positional params, extra ones are ignored like bitcoind's optional ones
+func rpcParams(params []json.RawMessage, required int, values ...any) *rpcError {
+	if len(params) < required {
+		return &rpcError{rpcInvalidParams, fmt.Sprintf("expected %d params, got %d", required, len(params))}
+	}
+	for index, value := range values {
+		if index >= len(params) {
+			break
+		}
+		if err := json.Unmarshal(params[index], value); err != nil {
+			return &rpcError{rpcInvalidParams, fmt.Sprintf("invalid param %d: %v", index+1, err)}
+		}
+	}
+	return nil
+}
+
+func rpcBackendError(what string, err error) *rpcError {
+	if errors.Is(err, store.ErrNotFound) {
+		return &rpcError{rpcInvalidAddressOrKey, fmt.Sprintf("no %s found", what)}
+	}
+	log.Errorf("rpc could not get %s", err, what)
+	return &rpcError{rpcInternalError, fmt.Sprintf("could not get %s", what)}
+}
+
+func rpcFailure(id json.RawMessage, code int, message string) *rpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &rpcResponse{JSONRPC: "2.0", Error: &rpcError{code, message}, ID: id}
+}
+
+func writeRPC(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error("rpc could not write response", err)
+	}
+}
+
+// Formats an amount of the smallest unit as coins, without float rounding
+func formatCoins(amount int64) json.Number {
+	return json.Number(utils.FormatCoins(amount))
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+const (
+	cReadTimeout    = 10 * time.Second
+	cWriteTimeout   = 30 * time.Second
+	cIdleTimeout    = 60 * time.Second
+	cMaxHeaderBytes = 16 * 1024
+)
+
+// Backend is what the API server needs from the node
+type Backend interface {
+	BlocksStatus() (*pb.APIBlocksStatus, error)
+	NetworkStatus() *pb.APINetworkStatus
+	Block(height uint64) (*pb.Block, error)
+	BlockByHash(hash string) (*pb.Block, error)
+	Blocks(from uint64, to uint64) ([]*pb.Block, error)
+	BlockTransactions(height uint64) ([]*pb.Transaction, error)
+	Transaction(hash string) (*pb.Transaction, error)
+	AddressBalance(address string) (*pb.APIAddressBalance, error)
+	AddressTransactions(address string, cursor string, limit int) (*pb.APIAddressTransactions, error)
+	SubmitTransaction(transaction *pb.Transaction) (string, error)
+	Peers() []*pb.PeerInfo
+
+	// Admin operations
+	RequestShutdown()
+	Rescan() error
+	ConnectPeer(address string) (*pb.PeerInfo, error)
+	DisconnectPeer(id string) error
+	BanPeer(id string) error
+	UnbanPeer(id string) error
+	BannedPeers() ([]*pb.PeerInfo, error)
+}
+
+type (
+	Server struct {
+		ctx        context.Context
+		shutdown   func()
+		wg         *sync.WaitGroup
+		server     *http.Server
+		apiAddress string
+		backend    Backend
+		events     *Events
+		// JSON-RPC basic auth credentials
+		rpcUser     string
+		rpcPassword string
+		auth        *auth
+	}
+)
+
+// Returns the URL path of an API endpoint
+func Route(endpoint string) string {
+	return "/" + path.Join(APIBasePath, endpoint)
+}
+
+func NewServer(
+	ctx context.Context,
+	shutdown func(),
+	wg *sync.WaitGroup,
+	backend Backend,
+	address string,
+	config *cfg.APIConfig,
+) (*Server, error) {
+	mux := http.NewServeMux()
+
+	auth, err := newAuth(config.Tokens, config.PublicReads)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := utils.NewTLSConfig(config.TLSCertFile, config.TLSKeyFile, config.TLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	api := &Server{
+		ctx:         ctx,
+		shutdown:    shutdown,
+		wg:          wg,
+		apiAddress:  address,
+		backend:     backend,
+		events:      NewEvents(),
+		rpcUser:     config.RPCUser,
+		rpcPassword: config.RPCPassword,
+		auth:        auth,
+	}
+
+	// Register routes
+	read := func(handler http.HandlerFunc) http.HandlerFunc { return auth.require(RoleRead, handler) }
+	admin := func(handler http.HandlerFunc) http.HandlerFunc { return auth.require(RoleAdmin, handler) }
+	// With a client CA, admin operations also need a certificate signed by it
+	if tlsConfig != nil && tlsConfig.ClientCAs != nil {
+		admin = func(handler http.HandlerFunc) http.HandlerFunc {
+			return utils.RequireClientCertificate(auth.require(RoleAdmin, handler)).ServeHTTP
+		}
+	}
+	mux.HandleFunc(Route(APIBlocksStatus), read(api.getBlocksStatusHandler))
+	mux.HandleFunc(Route(APINetworkStatus), read(api.getNetworkStatusHandler))
+	mux.HandleFunc(Route(APIBlocks), read(api.getBlocksHandler))
+	mux.HandleFunc(Route(APIBlock), read(api.getBlockHandler))
+	mux.HandleFunc(Route(APIBlockByHash), read(api.getBlockByHashHandler))
+	// APIBlockTransactions would conflict with APIBlockByHash on blocks/hash/transactions,
+	// so the handler matches the last segment
+	mux.HandleFunc(Route(cBlockSubPath), read(api.getBlockTransactionsHandler))
+	mux.HandleFunc(Route(APITransactions), read(api.transactionsHandler))
+	mux.HandleFunc(Route(APITransaction), read(api.getTransactionHandler))
+	mux.HandleFunc(Route(APIAddressBalance), read(api.getAddressBalanceHandler))
+	mux.HandleFunc(Route(APIAddressTransactions), read(api.getAddressTransactionsHandler))
+	mux.HandleFunc(Route(APIEvents), read(api.getEventsHandler))
+	mux.HandleFunc(Route(APIRescan), admin(api.postRescanHandler))
+	mux.HandleFunc(Route(APIShutdown), admin(api.postShutdownHandler))
+	mux.HandleFunc(Route(APIPeers), admin(api.peersHandler))
+	mux.HandleFunc(Route(APIPeer), admin(api.deletePeerHandler))
+	mux.HandleFunc(Route(APIBans), admin(api.getBansHandler))
+	mux.HandleFunc(Route(APIBan), admin(api.banHandler))
+	// JSON-RPC sits at the root like bitcoind's, only with credentials set
+	if config.RPCUser != "" && config.RPCPassword != "" {
+		mux.HandleFunc("/{$}", api.rpcHandler)
+	}
+	mux.HandleFunc("/", http.NotFound)
+
+	api.server = &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: cReadTimeout,
+		ReadTimeout:       cReadTimeout,
+		WriteTimeout:      cWriteTimeout,
+		IdleTimeout:       cIdleTimeout,
+		MaxHeaderBytes:    cMaxHeaderBytes,
+		TLSConfig:         tlsConfig,
+	}
+
+	return api, nil
+}
+
+// Starts the API server
+func (api *Server) Start() {
+	defer api.wg.Done()
+
+	listener, err := net.Listen("tcp", api.apiAddress)
+	if err != nil {
+		log.Error("api Listen", err)
+		api.shutdown()
+		return
+	}
+
+	if api.server.TLSConfig != nil {
+		log.Infof("api server: Listening on %s with TLS", api.apiAddress)
+		err = api.server.ServeTLS(listener, "", "")
+	} else {
+		log.Infof("api server: Listening on %s", api.apiAddress)
+		err = api.server.Serve(listener)
+	}
+	if err != nil && err != http.ErrServerClosed {
+		log.Error("api Serve", err)
+		api.shutdown()
+	}
+}
+
+// Shuts down the API server
+func (api *Server) ShutDown() {
+	log.Info("api server shuting down")
+	// Streams don't end on their own
+	api.events.Close()
+	if err := api.auth.removeCookie(); err != nil {
+		log.Error("api could not remove the cookie file", err)
+	}
+	if err := api.server.Shutdown(api.ctx); err != nil {
+		log.Error("api shutdown failed", err)
+	}
+}
+
+// WriteCookie writes an admin token to the cookie file, removed on shutdown
+func (api *Server) WriteCookie(path string) error {
+	return api.auth.writeCookie(path)
+}
+
+// Publish streams an event to the connected clients
+func (api *Server) Publish(event *pb.APIEvent) {
+	api.events.Publish(event)
+}
+
+// Returns the HTTP handler of the API server
+func (api *Server) Handler() http.Handler {
+	return api.server.Handler
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cTransactionMaxBodySize = 64 * 1024
+)
+
+// Lists transactions on GET, submits one on POST
+func (api *Server) transactionsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		api.getTransactionsHandler(w, r)
+	case http.MethodPost:
+		api.postTransactionHandler(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *Server) postTransactionHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("api submitting transaction")
+
+	transaction := &pb.Transaction{}
+	if err := pb.ReadMessage(w, r, cTransactionMaxBodySize, transaction); err != nil {
+		if errors.Is(err, pb.ErrUnsupportedMediaType) {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		pb.WriteNegotiatedStatus(w, r, http.StatusBadRequest, &pb.APIError{Error: "could not decode the transaction"})
+		return
+	}
+
+	hash, err := api.backend.SubmitTransaction(transaction)
+	var transactionErr *pb.TransactionError
+	switch {
+	case errors.As(err, &transactionErr):
+		pb.WriteNegotiatedStatus(w, r, http.StatusBadRequest, &pb.APIError{
+			Error: transactionErr.Error(),
+			Field: transactionErr.Field,
+		})
+	case errors.Is(err, pb.ErrTransactionKnown):
+		pb.WriteNegotiatedStatus(w, r, http.StatusConflict, &pb.APIError{Error: err.Error()})
+	case err != nil:
+		log.Error("api could not submit transaction", err)
+		pb.WriteNegotiatedStatus(w, r, http.StatusInternalServerError, &pb.APIError{Error: "could not submit the transaction"})
+	default:
+		pb.WriteNegotiatedStatus(w, r, http.StatusAccepted, &pb.APITransactionSubmitted{Hash: hash})
+	}
+}
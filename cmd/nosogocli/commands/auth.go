@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"github.com/Friends-Of-Noso/NosoGo/api"
+)
+
+// Token sent to the API, the one given as flag or else the node's cookie
+func apiToken() string {
+	if apiTokenFlag != "" {
+		return apiTokenFlag
+	}
+	token, err := api.ReadCookie(config.GetCookieFile())
+	if err != nil {
+		// No cookie means no node running here, reads may still be public
+		return ""
+	}
+	return token
+}
@@ -1,9 +1,18 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/spf13/cobra"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
 )
 
+// Blocks asked for at once, the most the API serves
+const cBlocksPage = 100
+
 var blocksCmd = &cobra.Command{
 	Use:   "blocks",
 	Short: "Blocks related queries",
@@ -20,8 +29,64 @@ func init() {
 	// and all subcommands, e.g.:
 	// blocksCmd.PersistentFlags().String("foo", "", "A help for foo")
 	blocksCmd.PersistentFlags().BoolP("json", "j", false, "Outputs results in 'JSON'")
+	blocksCmd.PersistentFlags().StringP("output", "o", cOutputTable, "Output format: table, json or text (protobuf text format)")
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// blocksCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
+
+// Prints a block with all its fields
+func printBlock(format string, block *pb.Block) error {
+	switch format {
+	case cOutputJSON:
+		return printJSON(block)
+	case cOutputText:
+		return printText(block)
+	}
+	return printFields([][2]string{
+		{"Height", strconv.FormatUint(block.Height, 10)},
+		{"Hash", block.Hash},
+		{"Previous hash", block.PreviousHash},
+		{"Time", formatTime(block.Timestamp)},
+		{"Merkle root", block.MerkleRoot},
+	})
+}
+
+// Prints blocks one per row, the header only when asked so rows can follow
+func printBlocks(format string, blocks []*pb.Block, header bool) error {
+	switch format {
+	case cOutputJSON:
+		if header {
+			return printJSON(blocks)
+		}
+		// Following, one object per line
+		for _, block := range blocks {
+			if err := printJSONLine(block); err != nil {
+				return err
+			}
+		}
+		return nil
+	case cOutputText:
+		for _, block := range blocks {
+			if err := printText(block); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if header {
+		fmt.Printf("%-10s  %-20s  %s\n", "HEIGHT", "TIME", "HASH")
+	}
+	for _, block := range blocks {
+		fmt.Printf("%-10d  %-20s  %s\n", block.Height, formatTime(block.Timestamp), block.Hash)
+	}
+	return nil
+}
+
+// Tells of a reorg apart from the blocks, so they can still be piped
+func printReorg(reorg *pb.APIEventReorg) error {
+	_, err := fmt.Fprintf(os.Stderr, "reorg at height %d: '%s' replaced by '%s'\n", reorg.Height, reorg.OldHash, reorg.NewHash)
+	return err
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+var blocksGetCmd = &cobra.Command{
+	Use:   "get <height|hash>",
+	Short: "Shows a block, by height or hash",
+	Example: `  $ nosogocli blocks get 1000
+  $ nosogocli blocks get BZERO -o text`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlocksGet,
+}
+
+func init() {
+	blocksCmd.AddCommand(blocksGetCmd)
+}
+
+func runBlocksGet(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	var block *pb.Block
+	if height, err := strconv.ParseUint(args[0], 10, 64); err == nil {
+		block, err = client.Block(cmd.Context(), height)
+		if err != nil {
+			return err
+		}
+	} else {
+		block, err = client.BlockByHash(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	return printBlock(format, block)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const cBlocksListDefault = 10
+
+var blocksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists blocks between two heights",
+	Long: `Lists the blocks between two heights, both included.
+Without '--to' the list ends at the last block, without '--from' it holds the last 10.`,
+	Example: `  $ nosogocli blocks list
+  $ nosogocli blocks list --from 1000 --to 1200 -j`,
+	Args: cobra.NoArgs,
+	RunE: runBlocksList,
+}
+
+func init() {
+	blocksCmd.AddCommand(blocksListCmd)
+
+	blocksListCmd.Flags().Uint64("from", 0, "First height of the list")
+	blocksListCmd.Flags().Uint64("to", 0, "Last height of the list")
+}
+
+func runBlocksList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	from, _ := cmd.Flags().GetUint64("from")
+	to, _ := cmd.Flags().GetUint64("to")
+	if !cmd.Flags().Changed("to") {
+		status, err := client.BlocksStatus(cmd.Context())
+		if err != nil {
+			return err
+		}
+		to = status.Height
+	}
+	if !cmd.Flags().Changed("from") {
+		from = to - min(to, cBlocksListDefault-1)
+	}
+	if to < from {
+		return fmt.Errorf("'--to' %d is below '--from' %d", to, from)
+	}
+
+	var blocks []*pb.Block
+	for start := from; start <= to; start += cBlocksPage {
+		end := min(to, start+cBlocksPage-1)
+		page, err := client.Blocks(cmd.Context(), start, end)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, page...)
+		// Stops before wrapping around at the top of the range
+		if end == to {
+			break
+		}
+	}
+
+	if format == cOutputText {
+		return printText(&pb.APIBlocks{Blocks: blocks})
+	}
+	return printBlocks(format, blocks, true)
+}
@@ -1,19 +1,16 @@
 package commands
 
 import (
-	"fmt"
-	"path"
+	"strconv"
 
 	"github.com/spf13/cobra"
-
-	"github.com/Friends-Of-Noso/NosoGo/api"
 )
 
 var blocksStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Blocks Status",
 	//Long:  `Initializes the configuration file.`,
-	Run: runBlocksStatus,
+	RunE: runBlocksStatus,
 }
 
 func init() {
@@ -30,17 +27,26 @@ func init() {
 	// blocksStatusCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
-func runBlocksStatus(cmd *cobra.Command, args []string) {
-	fmt.Println("blocks status called")
-	json, err := cmd.Flags().GetBool("json")
+func runBlocksStatus(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := client.BlocksStatus(cmd.Context())
 	if err != nil {
-		fmt.Printf("Error getting flag 'json': %v`n", err)
+		return err
 	}
 
-	if json {
-		fmt.Println("Output in 'JSON' format")
+	if format == cOutputJSON {
+		return printJSON(status)
 	}
-	path := path.Join(api.APIBasePath, api.APIBlocksStatus)
-	url := fmt.Sprintf("http://%s:%d/%s", config.API.Address, config.API.Port, path)
-	fmt.Printf("Endpoint: '%s'\n", url)
+	return printFields([][2]string{
+		{"Height", strconv.FormatUint(status.Height, 10)},
+		{"Last hash", status.LastHash},
+	})
 }
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Wait before following again a stream the node closed
+const cTailReconnectDelay = 2 * time.Second
+
+var blocksTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Shows the last blocks, and the new ones with '-f'",
+	Example: `  $ nosogocli blocks tail -n 20
+  $ nosogocli blocks tail -f -j`,
+	Args: cobra.NoArgs,
+	RunE: runBlocksTail,
+}
+
+func init() {
+	blocksCmd.AddCommand(blocksTailCmd)
+
+	blocksTailCmd.Flags().Uint64P("lines", "n", cBlocksListDefault, "Number of last blocks shown")
+	blocksTailCmd.Flags().BoolP("follow", "f", false, "Follows the new blocks until interrupted")
+}
+
+func runBlocksTail(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	lines, _ := cmd.Flags().GetUint64("lines")
+	follow, _ := cmd.Flags().GetBool("follow")
+
+	status, err := client.BlocksStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	var blocks []*pb.Block
+	if lines > 0 {
+		from := status.Height - min(status.Height, lines-1)
+		for start := from; start <= status.Height; start += cBlocksPage {
+			end := min(status.Height, start+cBlocksPage-1)
+			page, err := client.Blocks(ctx, start, end)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, page...)
+			if end == status.Height {
+				break
+			}
+		}
+	}
+	// Followed blocks come as they arrive, only a table gets a header first
+	if err := printBlocks(format, blocks, !follow || format == cOutputTable); err != nil {
+		return err
+	}
+	if !follow {
+		return nil
+	}
+
+	return followBlocks(ctx, client, format, status.Height+1)
+}
+
+// Prints the blocks from a height as they come, resuming when the node drops the stream
+func followBlocks(ctx context.Context, client *api.Client, format string, next uint64) error {
+	handle := func(event *pb.APIEvent) error {
+		switch payload := event.Payload.(type) {
+		case *pb.APIEvent_Block:
+			block := payload.Block.Block
+			if err := printBlocks(format, []*pb.Block{block}, false); err != nil {
+				return err
+			}
+			next = block.Height + 1
+		case *pb.APIEvent_Reorg:
+			if err := printReorg(payload.Reorg); err != nil {
+				return err
+			}
+			next = min(next, payload.Reorg.Height)
+		}
+		return nil
+	}
+
+	for {
+		err := client.Events(ctx, &next, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, api.ErrNodeUnreachable) {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "events stream ended: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cTailReconnectDelay):
+		}
+	}
+}
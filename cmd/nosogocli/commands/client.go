@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+)
+
+// Client for the node API set in the configuration
+func newAPIClient() (*api.Client, error) {
+	host := config.API.Address
+	// A node listening everywhere is reached locally
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+
+	scheme := "http"
+	var tlsConfig *tls.Config
+	if config.API.TLSCertFile != "" {
+		scheme = "https"
+		// Trust the node's own certificate, it's usually self signed
+		if pem, err := os.ReadFile(config.API.TLSCertFile); err == nil {
+			roots, err := x509.SystemCertPool()
+			if err != nil {
+				roots = x509.NewCertPool()
+			}
+			roots.AppendCertsFromPEM(pem)
+			tlsConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+		}
+	}
+
+	baseURL := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(host, strconv.Itoa(config.API.Port)))
+	return api.NewClient(baseURL, apiToken(), tlsConfig)
+}
@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"strconv"
+
 	"github.com/spf13/cobra"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
 )
 
 var networkCmd = &cobra.Command{
@@ -20,8 +24,40 @@ func init() {
 	// and all subcommands, e.g.:
 	// networkCmd.PersistentFlags().String("foo", "", "A help for foo")
 	networkCmd.PersistentFlags().BoolP("json", "j", false, "Outputs results in 'JSON'")
+	networkCmd.PersistentFlags().StringP("output", "o", cOutputTable, "Output format: table, json or text (protobuf text format)")
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// networkCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
+
+// Prints peers one per row
+func printPeers(format string, peers []*pb.PeerInfo) error {
+	switch format {
+	case cOutputJSON:
+		return printJSON(peers)
+	case cOutputText:
+		return printText(&pb.DNSPeersResponse{Peers: peers, Total: uint32(len(peers))})
+	}
+
+	rows := make([][]string, 0, len(peers))
+	for _, info := range peers {
+		address := info.Address
+		if address != "" && info.Port != 0 {
+			address += ":" + strconv.Itoa(int(info.Port))
+		}
+		latency := ""
+		if info.LatencyMs > 0 {
+			latency = strconv.FormatInt(info.LatencyMs, 10) + "ms"
+		}
+		rows = append(rows, []string{
+			info.Id,
+			address,
+			info.Mode,
+			info.Direction,
+			latency,
+			strconv.Itoa(int(info.Score)),
+		})
+	}
+	return printTable([]string{"ID", "ADDRESS", "MODE", "DIRECTION", "LATENCY", "SCORE"}, rows)
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var networkBanCmd = &cobra.Command{
+	Use:   "ban <peerID>",
+	Short: "Bans a peer",
+	Long:  `Drops the peer from the lists of the node, closes its connections and refuses new ones.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNetworkBan,
+}
+
+var networkUnbanCmd = &cobra.Command{
+	Use:   "unban <peerID>",
+	Short: "Lifts the ban of a peer",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNetworkUnban,
+}
+
+var networkBansCmd = &cobra.Command{
+	Use:   "bans",
+	Short: "Lists the banned peers",
+	Args:  cobra.NoArgs,
+	RunE:  runNetworkBans,
+}
+
+func init() {
+	networkCmd.AddCommand(networkBanCmd)
+	networkCmd.AddCommand(networkUnbanCmd)
+	networkCmd.AddCommand(networkBansCmd)
+}
+
+func runNetworkBan(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.BanPeer(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+	_, err = fmt.Printf("Banned peer '%s'\n", args[0])
+	return err
+}
+
+func runNetworkUnban(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.UnbanPeer(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+	_, err = fmt.Printf("Lifted the ban of peer '%s'\n", args[0])
+	return err
+}
+
+func runNetworkBans(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	peers, err := client.BannedPeers(cmd.Context())
+	if err != nil {
+		return err
+	}
+	return printPeers(format, peers)
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+var networkPeersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "Lists the peers of the node",
+	Long: `Lists the seeds and nodes known to the node and the other peers connected to it.
+Disconnected peers show no direction.`,
+	Args: cobra.NoArgs,
+	RunE: runNetworkPeers,
+}
+
+var networkConnectCmd = &cobra.Command{
+	Use:     "connect <multiaddr>",
+	Short:   "Connects the node to a peer",
+	Example: `  $ nosogocli network connect /ip4/10.0.0.1/tcp/45050/p2p/<peerID>`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runNetworkConnect,
+}
+
+var networkDisconnectCmd = &cobra.Command{
+	Use:   "disconnect <peerID>",
+	Short: "Disconnects the node from a peer",
+	Long: `Closes the connections of the node to a peer.
+The peer stays known and may connect again, ban it to keep it away.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNetworkDisconnect,
+}
+
+func init() {
+	networkCmd.AddCommand(networkPeersCmd)
+	networkCmd.AddCommand(networkConnectCmd)
+	networkCmd.AddCommand(networkDisconnectCmd)
+}
+
+func runNetworkPeers(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	peers, err := client.Peers(cmd.Context())
+	if err != nil {
+		return err
+	}
+	return printPeers(format, peers)
+}
+
+func runNetworkConnect(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	info, err := client.ConnectPeer(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	if format != cOutputTable {
+		return printPeers(format, []*pb.PeerInfo{info})
+	}
+	_, err = fmt.Printf("Connected to peer '%s'\n", info.Id)
+	return err
+}
+
+func runNetworkDisconnect(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.DisconnectPeer(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+	_, err = fmt.Printf("Disconnected peer '%s'\n", args[0])
+	return err
+}
@@ -1,19 +1,16 @@
 package commands
 
 import (
-	"fmt"
-	"path"
+	"strconv"
 
 	"github.com/spf13/cobra"
-
-	"github.com/Friends-Of-Noso/NosoGo/api"
 )
 
 var networkStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Network Status",
 	//Long:  `Initializes the configuration file.`,
-	Run: runNetworkStatus,
+	RunE: runNetworkStatus,
 }
 
 func init() {
@@ -30,18 +27,31 @@ func init() {
 	// networkStatusCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
-func runNetworkStatus(cmd *cobra.Command, args []string) {
-	fmt.Println("network status called")
-	json, err := cmd.Flags().GetBool("json")
+func runNetworkStatus(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
 	if err != nil {
-		fmt.Printf("Error getting flag 'json': %v`n", err)
+		return err
 	}
 
-	if json {
-		fmt.Println("Output in 'JSON' format")
+	status, err := client.NetworkStatus(cmd.Context())
+	if err != nil {
+		return err
 	}
 
-	path := path.Join(api.APIBasePath, api.APINetworkStatus)
-	url := fmt.Sprintf("http://%s:%d/%s", config.API.Address, config.API.Port, path)
-	fmt.Printf("Endpoint: '%s'\n", url)
+	if format == cOutputJSON {
+		return printJSON(status)
+	}
+	return printFields([][2]string{
+		{"Network", status.Network},
+		{"Mode", status.Mode},
+		{"Peer ID", status.PeerId},
+		{"Connected peers", strconv.FormatUint(uint64(status.ConnectedPeers), 10)},
+		{"DNS peers", strconv.FormatUint(uint64(status.DnsPeers), 10)},
+		{"Seed peers", strconv.FormatUint(uint64(status.SeedPeers), 10)},
+		{"Node peers", strconv.FormatUint(uint64(status.NodePeers), 10)},
+	})
 }
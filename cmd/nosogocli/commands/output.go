@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cOutputTable = "table"
+	cOutputJSON  = "json"
+	cOutputText  = "text"
+)
+
+// Tells the format results are wanted in, `--json` being a shorthand of `--output json`
+func outputFormat(cmd *cobra.Command) (string, error) {
+	if asJSON, err := cmd.Flags().GetBool("json"); err == nil && asJSON {
+		return cOutputJSON, nil
+	}
+
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return cOutputTable, nil
+	}
+	switch format {
+	case cOutputTable, cOutputJSON, cOutputText:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid output format '%s', expected %s, %s or %s", format, cOutputTable, cOutputJSON, cOutputText)
+}
+
+// Prints a value as indented JSON
+func printJSON(v any) error {
+	data, err := marshalJSON(v)
+	if err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return err
+	}
+	_, err = fmt.Println(indented.String())
+	return err
+}
+
+// Prints a value as JSON on a single line
+func printJSONLine(v any) error {
+	data, err := marshalJSON(v)
+	if err != nil {
+		return err
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return err
+	}
+	_, err = fmt.Println(compacted.String())
+	return err
+}
+
+// Encodes messages the way the node API does, so zero values are kept
+func marshalJSON(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case proto.Message:
+		return pb.MarshalJSON(v)
+	case []*pb.Block:
+		return marshalJSONList(v)
+	case []*pb.PeerInfo:
+		return marshalJSONList(v)
+	}
+	return json.Marshal(v)
+}
+
+// Encodes a list of messages as a JSON array
+func marshalJSONList[T proto.Message](msgs []T) ([]byte, error) {
+	list := make([]json.RawMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		data, err := pb.MarshalJSON(msg)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, data)
+	}
+	return json.Marshal(list)
+}
+
+// Prints a message in the protobuf text format
+func printText(msg proto.Message) error {
+	_, err := fmt.Println(prototext.MarshalOptions{Multiline: true}.Format(msg))
+	return err
+}
+
+// Prints name and value pairs aligned in two columns
+func printFields(fields [][2]string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(w, "%s:\t%s\n", field[0], field[1])
+	}
+	return w.Flush()
+}
+
+// Prints rows under a header, aligned in columns
+func printTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// Formats a unix timestamp for people
+func formatTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
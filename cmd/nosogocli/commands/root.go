@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,14 +10,21 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/Friends-Of-Noso/NosoGo/api"
 	cfg "github.com/Friends-Of-Noso/NosoGo/config"
 	"github.com/Friends-Of-Noso/NosoGo/utils"
 	ver "github.com/Friends-Of-Noso/NosoGo/version"
 )
 
+const (
+	cExitError       = 1
+	cExitUnreachable = 2
+)
+
 var (
-	config  = cfg.DefaultConfig()
-	cfgFile string
+	config       = cfg.DefaultConfig()
+	cfgFile      string
+	apiTokenFlag string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -24,6 +32,9 @@ var rootCmd = &cobra.Command{
 	Version: ver.Version,
 	Use:     fmt.Sprintf("%scli", ver.Name),
 	Short:   "The client for the NOSO crypto coin node",
+	// Errors are reported once by Execute, without the usage
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
@@ -33,9 +44,16 @@ var rootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if errors.Is(err, api.ErrNodeUnreachable) {
+		fmt.Fprintf(os.Stderr, "Is the node running and its API enabled? See '%s --help'\n", rootCmd.Name())
+		os.Exit(cExitUnreachable)
 	}
+	os.Exit(cExitError)
 }
 
 func init() {
@@ -47,6 +65,7 @@ func init() {
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
+	rootCmd.PersistentFlags().StringVar(&apiTokenFlag, "api-token", "", "API token, defaults to the node's cookie")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+	"github.com/Friends-Of-Noso/NosoGo/wallet"
+)
+
+// Keys are forgotten past it, even if the node takes long to answer
+const cWalletUnlockTimeout = time.Minute
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Wallet related commands",
+	Long: `Manages the addresses of a local wallet, its keys encrypted with a passphrase.
+Transactions are signed locally and only the signed transaction is sent to the node.`,
+}
+
+func init() {
+	rootCmd.AddCommand(walletCmd)
+
+	walletCmd.PersistentFlags().BoolP("json", "j", false, "Outputs results in 'JSON'")
+	walletCmd.PersistentFlags().StringP("output", "o", cOutputTable, "Output format: table or json")
+	walletCmd.PersistentFlags().String("wallet", "", "Wallet file, defaults to the one in the config folder")
+	walletCmd.PersistentFlags().String("passphrase-file", "", "Reads the passphrase from a file instead of asking for it")
+}
+
+// The wallet file given, or the one in the config folder
+func walletFile(cmd *cobra.Command) string {
+	if file, _ := cmd.Flags().GetString("wallet"); file != "" {
+		return file
+	}
+	return config.GetWalletFile()
+}
+
+// Loads the wallet locked, its accounts don't need the passphrase
+func loadWallet(cmd *cobra.Command) (*wallet.Wallet, error) {
+	file := walletFile(cmd)
+	w, err := wallet.Load(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no wallet at '%s', create it with 'wallet new'", file)
+	}
+	return w, err
+}
+
+// Opens the wallet unlocked, creating it if asked to and it doesn't exist yet
+func openWallet(cmd *cobra.Command, create bool) (*wallet.Wallet, error) {
+	file := walletFile(cmd)
+	if _, err := os.Stat(file); create && errors.Is(err, os.ErrNotExist) {
+		passphrase, err := readPassphrase(cmd, "passphrase-file", "Passphrase: ", true)
+		if err != nil {
+			return nil, err
+		}
+		return wallet.Create(file, passphrase)
+	}
+
+	w, err := loadWallet(cmd)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := readPassphrase(cmd, "passphrase-file", "Passphrase: ", false)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Unlock(passphrase, cWalletUnlockTimeout); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Reads the passphrase from the file of the flag, else asks for it without echoing it
+func readPassphrase(cmd *cobra.Command, fileFlag string, prompt string, confirm bool) (string, error) {
+	if file, _ := cmd.Flags().GetString(fileFlag); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		// Piped in, a line is the passphrase
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("could not read the passphrase: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	passphrase, err := promptPassphrase(fd, prompt)
+	if err != nil {
+		return "", err
+	}
+	if !confirm {
+		return passphrase, nil
+	}
+	if passphrase == "" {
+		return "", errors.New("the passphrase can't be empty")
+	}
+	again, err := promptPassphrase(fd, "Repeat the passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if again != passphrase {
+		return "", errors.New("the passphrases don't match")
+	}
+	return passphrase, nil
+}
+
+func promptPassphrase(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("could not read the passphrase: %w", err)
+	}
+	return string(data), nil
+}
+
+// Prints accounts one per row, with their balance when given
+func printAccounts(format string, accounts []wallet.Account, balances map[string]int64) error {
+	if format == cOutputJSON {
+		if balances == nil {
+			return printJSON(accounts)
+		}
+		type accountBalance struct {
+			wallet.Account
+			Balance string `json:"balance"`
+		}
+		list := make([]accountBalance, 0, len(accounts))
+		for _, account := range accounts {
+			list = append(list, accountBalance{account, utils.FormatCoins(balances[account.Address])})
+		}
+		return printJSON(list)
+	}
+
+	header := []string{"ADDRESS", "NAME"}
+	if balances != nil {
+		header = append(header, "BALANCE")
+	}
+	rows := make([][]string, 0, len(accounts))
+	for _, account := range accounts {
+		row := []string{account.Address, account.Name}
+		if balances != nil {
+			row = append(row, utils.FormatCoins(balances[account.Address]))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(header, rows)
+}
+
+// The output format, the protobuf text one doesn't apply to wallets
+func walletOutputFormat(cmd *cobra.Command) (string, error) {
+	format, err := outputFormat(cmd)
+	if err == nil && format == cOutputText {
+		return "", fmt.Errorf("invalid output format '%s', expected %s or %s", format, cOutputTable, cOutputJSON)
+	}
+	return format, err
+}
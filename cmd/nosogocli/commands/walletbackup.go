@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var walletBackupCmd = &cobra.Command{
+	Use:   "backup <file>",
+	Short: "Copies the wallet to a file",
+	Long: `Copies the wallet, its keys still encrypted, to a file that must not exist yet.
+The backup opens with the passphrase the wallet has now.`,
+	Example: `  $ nosogocli wallet backup /media/usb/wallet-backup.json`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWalletBackup,
+}
+
+func init() {
+	walletCmd.AddCommand(walletBackupCmd)
+}
+
+func runWalletBackup(cmd *cobra.Command, args []string) error {
+	w, err := loadWallet(cmd)
+	if err != nil {
+		return err
+	}
+	if err := w.Backup(args[0]); err != nil {
+		return err
+	}
+
+	_, err = fmt.Printf("Wallet backed up to %s\n", args[0])
+	return err
+}
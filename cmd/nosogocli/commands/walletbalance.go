@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Friends-Of-Noso/NosoGo/wallet"
+)
+
+var walletBalanceCmd = &cobra.Command{
+	Use:   "balance [address]",
+	Short: "Shows the balance of the addresses of the wallet",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runWalletBalance,
+}
+
+func init() {
+	walletCmd.AddCommand(walletBalanceCmd)
+}
+
+func runWalletBalance(cmd *cobra.Command, args []string) error {
+	format, err := walletOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	w, err := loadWallet(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	accounts := w.Accounts()
+	if len(args) == 1 {
+		account, err := w.Account(args[0])
+		if err != nil {
+			return err
+		}
+		accounts = []wallet.Account{account}
+	}
+
+	balances := make(map[string]int64, len(accounts))
+	for _, account := range accounts {
+		balance, err := client.AddressBalance(cmd.Context(), account.Address)
+		if err != nil {
+			return err
+		}
+		balances[account.Address] = balance.Balance
+	}
+
+	return printAccounts(format, accounts, balances)
+}
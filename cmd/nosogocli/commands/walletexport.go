@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	"github.com/Friends-Of-Noso/NosoGo/wallet"
+)
+
+var walletExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Exports the private keys in clear",
+	Long: `Writes every address of the wallet with its private key, NOT encrypted, as JSON
+to a file that must not exist yet. Anyone reading that file can spend the coins,
+prefer 'wallet backup' to keep a copy of the wallet.
+With '--pkw' the file is a wallet.pkw the Noso wallet opens instead.`,
+	Example: `  $ nosogocli wallet export keys.json
+  $ nosogocli wallet export --pkw wallet.pkw`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWalletExport,
+}
+
+func init() {
+	walletCmd.AddCommand(walletExportCmd)
+
+	walletExportCmd.Flags().Bool("pkw", false, "Exports a wallet.pkw file of the Noso wallet")
+}
+
+func runWalletExport(cmd *cobra.Command, args []string) error {
+	w, err := openWallet(cmd, false)
+	if err != nil {
+		return err
+	}
+	defer w.Lock()
+
+	var count int
+	if pkw, _ := cmd.Flags().GetBool("pkw"); pkw {
+		entries, err := w.ExportLegacy()
+		if err != nil {
+			return err
+		}
+		if err := legacy.WriteWalletFile(args[0], entries); err != nil {
+			return err
+		}
+		count = len(entries)
+	} else {
+		keys, err := w.Export()
+		if err != nil {
+			return err
+		}
+		if err := writeKeysFile(args[0], keys); err != nil {
+			return err
+		}
+		count = len(keys)
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: %s holds the private keys in clear, keep it safe or delete it\n", args[0])
+	_, err = fmt.Printf("Exported %d keys to %s\n", count, args[0])
+	return err
+}
+
+// Writes the keys as JSON to a file that must not exist yet
+func writeKeysFile(path string, keys []wallet.ExportedKey) error {
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
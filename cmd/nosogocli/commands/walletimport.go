@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+)
+
+// Keys are a few dozen characters, anything longer isn't one
+const cMaxKeyFileSize = 4096
+
+var walletImportCmd = &cobra.Command{
+	Use:   "import <private-key-file>",
+	Short: "Imports a private key",
+	Long: `Imports a private key, hex or base64 encoded, read from a file or '-' for the standard input.
+Keys aren't taken as arguments so they don't end up in the shell history.
+Without '--address' the key gets its standard address.
+With '--pkw' the file is a wallet.pkw of the Noso wallet, every address is imported.`,
+	Example: `  $ nosogocli wallet import key.txt --name old-wallet
+  $ nosogocli wallet import - --address NuxYnPPYEqFMw3UM8j3hLppXsF8dEk < key.txt
+  $ nosogocli wallet import --pkw NOSODATA/wallet.pkw`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWalletImport,
+}
+
+func init() {
+	walletCmd.AddCommand(walletImportCmd)
+
+	walletImportCmd.Flags().String("name", "", "Name of the address")
+	walletImportCmd.Flags().String("address", "", "Address of the key, when not the standard one")
+	walletImportCmd.Flags().Bool("pkw", false, "Imports a wallet.pkw file of the Noso wallet")
+}
+
+func runWalletImport(cmd *cobra.Command, args []string) error {
+	format, err := walletOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if pkw, _ := cmd.Flags().GetBool("pkw"); pkw {
+		return importLegacyWallet(cmd, format, args[0])
+	}
+
+	var data []byte
+	if args[0] == "-" {
+		if passphraseFile, _ := cmd.Flags().GetString("passphrase-file"); passphraseFile == "" {
+			return errors.New("reading the key from the standard input needs '--passphrase-file'")
+		}
+		data, err = io.ReadAll(io.LimitReader(os.Stdin, cMaxKeyFileSize))
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	w, err := openWallet(cmd, true)
+	if err != nil {
+		return err
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	address, _ := cmd.Flags().GetString("address")
+	account, err := w.Import(strings.TrimSpace(string(data)), address, name)
+	if err != nil {
+		return err
+	}
+
+	if format == cOutputJSON {
+		return printJSON(account)
+	}
+	_, err = fmt.Printf("Imported address: %s\n", account.Address)
+	return err
+}
+
+// Imports every address of a wallet.pkw file
+func importLegacyWallet(cmd *cobra.Command, format string, file string) error {
+	if file == "-" {
+		return errors.New("a wallet.pkw file can't be read from the standard input")
+	}
+	entries, err := legacy.ReadWalletFile(file)
+	if err != nil {
+		return err
+	}
+
+	w, err := openWallet(cmd, true)
+	if err != nil {
+		return err
+	}
+	accounts, err := w.ImportLegacy(entries)
+	if err != nil {
+		return err
+	}
+
+	if format == cOutputJSON {
+		return printJSON(accounts)
+	}
+	if skipped := len(entries) - len(accounts); skipped > 0 {
+		fmt.Printf("Skipped %d addresses already in the wallet\n", skipped)
+	}
+	_, err = fmt.Printf("Imported %d addresses\n", len(accounts))
+	return err
+}
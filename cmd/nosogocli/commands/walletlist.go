@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var walletListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the addresses of the wallet",
+	Args:  cobra.NoArgs,
+	RunE:  runWalletList,
+}
+
+func init() {
+	walletCmd.AddCommand(walletListCmd)
+}
+
+func runWalletList(cmd *cobra.Command, args []string) error {
+	format, err := walletOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	w, err := loadWallet(cmd)
+	if err != nil {
+		return err
+	}
+
+	return printAccounts(format, w.Accounts(), nil)
+}
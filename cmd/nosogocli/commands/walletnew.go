@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var walletNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Creates a new address",
+	Long: `Generates a new keypair and stores it in the wallet.
+The wallet is created on the first use, asking for the passphrase twice.`,
+	Example: `  $ nosogocli wallet new --name savings`,
+	Args:    cobra.NoArgs,
+	RunE:    runWalletNew,
+}
+
+func init() {
+	walletCmd.AddCommand(walletNewCmd)
+
+	walletNewCmd.Flags().String("name", "", "Name of the address")
+}
+
+func runWalletNew(cmd *cobra.Command, args []string) error {
+	format, err := walletOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	w, err := openWallet(cmd, true)
+	if err != nil {
+		return err
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	account, err := w.NewAccount(name)
+	if err != nil {
+		return err
+	}
+
+	if format == cOutputJSON {
+		return printJSON(account)
+	}
+	_, err = fmt.Printf("New address: %s\n", account.Address)
+	return err
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var walletPassphraseCmd = &cobra.Command{
+	Use:   "passphrase",
+	Short: "Changes the passphrase of the wallet",
+	Long: `Encrypts every key of the wallet again under a new passphrase.
+Backups made before keep opening with the previous passphrase.`,
+	Example: `  $ nosogocli wallet passphrase
+  $ nosogocli wallet passphrase --passphrase-file old.txt --new-passphrase-file new.txt`,
+	Args: cobra.NoArgs,
+	RunE: runWalletPassphrase,
+}
+
+func init() {
+	walletCmd.AddCommand(walletPassphraseCmd)
+
+	walletPassphraseCmd.Flags().String("new-passphrase-file", "", "Reads the new passphrase from a file instead of asking for it")
+}
+
+func runWalletPassphrase(cmd *cobra.Command, args []string) error {
+	passphraseFile, _ := cmd.Flags().GetString("passphrase-file")
+	newPassphraseFile, _ := cmd.Flags().GetString("new-passphrase-file")
+	if (passphraseFile == "") != (newPassphraseFile == "") {
+		return errors.New("'--passphrase-file' and '--new-passphrase-file' go together")
+	}
+
+	w, err := openWallet(cmd, false)
+	if err != nil {
+		return err
+	}
+	defer w.Lock()
+
+	passphrase, err := readPassphrase(cmd, "new-passphrase-file", "New passphrase: ", true)
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return errors.New("the passphrase can't be empty")
+	}
+	if err := w.ChangePassphrase(passphrase); err != nil {
+		return err
+	}
+
+	_, err = fmt.Println("Passphrase changed")
+	return err
+}
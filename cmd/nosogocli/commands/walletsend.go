@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+	"github.com/Friends-Of-Noso/NosoGo/wallet"
+)
+
+var walletSendCmd = &cobra.Command{
+	Use:   "send <to> <amount>",
+	Short: "Sends coins to an address",
+	Long: `Signs a transfer from an address of the wallet and submits it to the node.
+The amount is in coins, with up to 8 decimals. With more than one address in the
+wallet, '--from' tells which one pays.`,
+	Example: `  $ nosogocli wallet send NuxYnPPYEqFMw3UM8j3hLppXsF8dEk 12.5
+  $ nosogocli wallet send NuxYnPPYEqFMw3UM8j3hLppXsF8dEk 0.0001 --from <address>`,
+	Args: cobra.ExactArgs(2),
+	RunE: runWalletSend,
+}
+
+func init() {
+	walletCmd.AddCommand(walletSendCmd)
+
+	walletSendCmd.Flags().String("from", "", "Address of the wallet paying")
+}
+
+func runWalletSend(cmd *cobra.Command, args []string) error {
+	format, err := walletOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	to := args[0]
+	if !legacy.IsValidHashAddress(to) {
+		return fmt.Errorf("invalid address '%s'", to)
+	}
+	amount, err := utils.ParseCoins(args[1])
+	if err != nil {
+		return err
+	}
+	if amount == 0 {
+		return errors.New("the amount must be more than zero")
+	}
+
+	w, err := openWallet(cmd, false)
+	if err != nil {
+		return err
+	}
+	from, _ := cmd.Flags().GetString("from")
+	account, err := payingAccount(w, from)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	balance, err := client.AddressBalance(cmd.Context(), account.Address)
+	if err != nil {
+		return err
+	}
+	if balance.Balance < int64(amount) {
+		return fmt.Errorf("'%s' only has %s", account.Address, utils.FormatCoins(balance.Balance))
+	}
+
+	privateKey, err := w.PrivateKey(account.Address)
+	w.Lock()
+	if err != nil {
+		return err
+	}
+	transaction := &pb.Transaction{
+		Type:      pb.TransactionTypeTransfer,
+		Timestamp: time.Now().Unix(),
+		Sender:    account.Address,
+		Receiver:  to,
+		Amount:    amount,
+	}
+	if err := transaction.Sign(privateKey, account.PublicKey); err != nil {
+		return err
+	}
+	// Catches a bad key before the node does
+	if err := transaction.ValidatePending(transaction.Timestamp); err != nil {
+		return err
+	}
+
+	hash, err := client.SubmitTransaction(cmd.Context(), transaction)
+	if err != nil {
+		return err
+	}
+
+	if format == cOutputJSON {
+		return printJSON(&pb.APITransactionSubmitted{Hash: hash})
+	}
+	_, err = fmt.Printf("Sent %s from %s to %s\nTransaction: %s\n", utils.FormatCoins(int64(amount)), account.Address, to, hash)
+	return err
+}
+
+// The account given, or the only one of the wallet
+func payingAccount(w *wallet.Wallet, from string) (wallet.Account, error) {
+	if from != "" {
+		return w.Account(from)
+	}
+
+	accounts := w.Accounts()
+	switch len(accounts) {
+	case 0:
+		return wallet.Account{}, errors.New("the wallet has no address, create one with 'wallet new'")
+	case 1:
+		return accounts[0], nil
+	}
+	return wallet.Account{}, errors.New("the wallet has several addresses, tell which one pays with '--from'")
+}
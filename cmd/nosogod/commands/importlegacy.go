@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacyimport"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+const cImportBatchSizeFlag = "batch-size"
+
+// importLegacyCmd represents the import-legacy command
+var importLegacyCmd = &cobra.Command{
+	Use:   "import-legacy [NOSODATA folder]",
+	Short: "Imports the blocks of a Pascal node into the database",
+	Long: `Imports the blocks of a Pascal node into the database, in height order.
+The folder holds the BLOCKS folder and blchhead.nos, it defaults to the one the legacy bridge downloads to.
+Blocks are checked against each other and blchhead.nos, the balances they add up to aren't checked
+against the summary file.
+An interrupted import resumes from the last batch written. The node must not be running.`,
+	Example: `  # Importing the chain of a Pascal wallet or node
+  $ nosogod import-legacy ~/Noso/NOSODATA
+
+  # Importing the chain downloaded by the legacy bridge
+  $ nosogod import-legacy`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runImportLegacy,
+}
+
+func init() {
+	rootCmd.AddCommand(importLegacyCmd)
+
+	importLegacyCmd.Flags().StringVarP(&cfgFile, cConfigFlag, "c", config.GetConfigFile(), "config file")
+	importLegacyCmd.Flags().Int(cImportBatchSizeFlag, legacyimport.DefaultBatchSize, "blocks stored in one write")
+}
+
+func runImportLegacy(cmd *cobra.Command, args []string) {
+	nodeInitConfigAndLogs(cmd)
+
+	folder := config.GetLegacyDataFolder()
+	if len(args) > 0 {
+		folder = args[0]
+	}
+	if info, err := os.Stat(folder); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "could not find folder '%s'\n", folder)
+		os.Exit(1)
+	}
+
+	sm, err := store.NewStorageManager(config.GetDatabasePath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not open database, is the node running? %v\n", err)
+		os.Exit(1)
+	}
+	defer sm.Close()
+
+	// Interrupting writes what was read, the next run resumes from there
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	importer := legacyimport.NewImporter(sm, folder, getFlagInt(cmd, cImportBatchSizeFlag), func(last uint64) {
+		fmt.Fprintf(os.Stderr, "Imported up to block %d\n", last)
+	})
+	checkpoint, err := importer.Checkpoint()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not read checkpoint: %v\n", err)
+		os.Exit(1)
+	}
+	if checkpoint != nil {
+		fmt.Fprintf(os.Stderr, "Resuming after block %d\n", checkpoint.LastBlock)
+	}
+
+	result, err := importer.Import(ctx)
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "Interrupted, run again to resume")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not import '%s': %v\n", folder, err)
+		os.Exit(1)
+	}
+	if result.Blocks == 0 {
+		fmt.Fprintf(os.Stderr, "No blocks to import from '%s'\n", folder)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Imported blocks %d to %d, %d transactions\n", result.First, result.Last, result.Transactions)
+}
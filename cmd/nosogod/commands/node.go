@@ -30,11 +30,55 @@ const (
 	cNodeModeFlag    = "node-mode"
 	cNodeMode        = "node.mode"
 
+	cAPIAddressFlag     = "api-address"
+	cAPIAddress         = "api.address"
+	cAPIPortFlag        = "api-port"
+	cAPIPort            = "api.port"
+	cAPIGRPCPortFlag    = "api-grpc-port"
+	cAPIGRPCPort        = "api.grpc-port"
+	cAPIRPCUserFlag     = "api-rpc-user"
+	cAPIRPCUser         = "api.rpc-user"
+	cAPIRPCPasswordFlag = "api-rpc-password"
+	cAPIRPCPassword     = "api.rpc-password"
+	cAPITokensFlag      = "api-token"
+	cAPITokens          = "api.tokens"
+	cAPIPublicReadsFlag = "api-public-reads"
+	cAPIPublicReads     = "api.public-reads"
+
+	cAPITLSCertFileFlag     = "api-tls-cert-file"
+	cAPITLSCertFile         = "api.tls-cert-file"
+	cAPITLSKeyFileFlag      = "api-tls-key-file"
+	cAPITLSKeyFile          = "api.tls-key-file"
+	cAPITLSClientCAFileFlag = "api-tls-client-ca-file"
+	cAPITLSClientCAFile     = "api.tls-client-ca-file"
+
+	cNodeDNSServersFlag = "dns-server"
+	cNodeDNSServers     = "node.dns-servers"
+
 	cDNSAddressFlag = "dns-address"
 	cDNSAddress     = "dns.address"
 	cDNSPortFlag    = "dns-port"
 	cDNSPort        = "dns.port"
 
+	cDNSSeedDomainFlag    = "dns-seed-domain"
+	cDNSSeedDomain        = "dns.seed-domain"
+	cDNSResponderPortFlag = "dns-responder-port"
+	cDNSResponderPort     = "dns.responder-port"
+
+	cDNSTLSCertFileFlag     = "dns-tls-cert-file"
+	cDNSTLSCertFile         = "dns.tls-cert-file"
+	cDNSTLSKeyFileFlag      = "dns-tls-key-file"
+	cDNSTLSKeyFile          = "dns.tls-key-file"
+	cDNSTLSClientCAFileFlag = "dns-tls-client-ca-file"
+	cDNSTLSClientCAFile     = "dns.tls-client-ca-file"
+
+	cLegacyAddressFlag = "legacy-address"
+	cLegacyAddress     = "legacy.address"
+	cLegacyPortFlag    = "legacy-port"
+	cLegacyPort        = "legacy.port"
+	cLegacyNodesFlag   = "legacy-node"
+	cLegacyNodes       = "legacy.nodes"
+
 	// cSeedFlag = "seed" // Needs removal in production
 )
 
@@ -58,9 +102,36 @@ var (
   $ nosogod node --node-address "localhost" --node-port 1234
   $ nosogod node --node-address "127.0.0.1" --node-port 4321
 
+  # Serving the API on a different address/port combination
+  $ nosogod node --api-address "127.0.0.1" --api-port 5432
+
+  # Serving the gRPC API on another port, or not at all with 0
+  $ nosogod node --api-grpc-port 5433
+
+  # Enabling the JSON-RPC endpoint at the API root
+  $ nosogod node --api-rpc-user "exchange" --api-rpc-password "secret"
+
+  # Requiring a token for reads too, besides the cookie for admin operations
+  $ nosogod node --api-public-reads=false --api-token "read:explorer-secret" --api-token "admin:ops-secret"
+
+  # Serving the API over TLS, admin operations also needing a client certificate signed by the CA
+  $ nosogod node --api-tls-cert-file cert.pem --api-tls-key-file key.pem --api-tls-client-ca-file ca.pem
+
   # In mode DNS using different address/port combinations
   $ nosogod node --node.mode "dns" --dns-address "localhost" --dns-port 1234
-  $ nosogod node --node.mode "dns" --dns-address "127.0.0.1" --dns-port 4321`,
+  $ nosogod node --node.mode "dns" --dns-address "127.0.0.1" --dns-port 4321
+
+  # In mode DNS also answering standard DNS queries for a seed domain
+  $ nosogod node --node-mode "dns" --dns-seed-domain "seeds.example.com" --dns-responder-port 8053
+
+  # Bootstrap from several DNS servers
+  $ nosogod node --dns-server "https://dns1.example.com" --dns-server "https://dns2.example.com"
+
+  # Bridging to the Pascal nodes of the Noso network
+  $ nosogod node --legacy-port 8080 --legacy-node "192.0.2.10:8080" --legacy-node "192.0.2.11:8080"
+
+  # DNS mode over TLS, with admin endpoints for clients signed by the CA
+  $ nosogod node --node-mode "dns" --dns-tls-cert-file cert.pem --dns-tls-key-file key.pem --dns-tls-client-ca-file ca.pem`,
 		Run: runNode,
 	}
 	seed string
@@ -130,12 +201,69 @@ func init() {
 		log.Error("Error registering flag completion function", err)
 	}
 
+	nodeCmd.Flags().String(cAPIAddressFlag, config.API.Address, "api address")
+	viper.BindPFlag(cAPIAddress, nodeCmd.Flags().Lookup(cAPIAddressFlag))
+
+	nodeCmd.Flags().Int(cAPIPortFlag, config.API.Port, "api port")
+	viper.BindPFlag(cAPIPort, nodeCmd.Flags().Lookup(cAPIPortFlag))
+
+	nodeCmd.Flags().Int(cAPIGRPCPortFlag, config.API.GRPCPort, "api gRPC port, 0 disables it")
+	viper.BindPFlag(cAPIGRPCPort, nodeCmd.Flags().Lookup(cAPIGRPCPortFlag))
+
+	nodeCmd.Flags().String(cAPIRPCUserFlag, config.API.RPCUser, "api JSON-RPC user, enables it along with the password")
+	viper.BindPFlag(cAPIRPCUser, nodeCmd.Flags().Lookup(cAPIRPCUserFlag))
+
+	nodeCmd.Flags().String(cAPIRPCPasswordFlag, config.API.RPCPassword, "api JSON-RPC password")
+	viper.BindPFlag(cAPIRPCPassword, nodeCmd.Flags().Lookup(cAPIRPCPasswordFlag))
+
+	nodeCmd.Flags().StringSlice(cAPITokensFlag, config.API.Tokens, "api token as 'read:token' or 'admin:token', can be repeated")
+	viper.BindPFlag(cAPITokens, nodeCmd.Flags().Lookup(cAPITokensFlag))
+
+	nodeCmd.Flags().Bool(cAPIPublicReadsFlag, config.API.PublicReads, "api reads need no token")
+	viper.BindPFlag(cAPIPublicReads, nodeCmd.Flags().Lookup(cAPIPublicReadsFlag))
+
+	nodeCmd.Flags().String(cAPITLSCertFileFlag, config.API.TLSCertFile, "api TLS certificate file, reloaded when changed")
+	viper.BindPFlag(cAPITLSCertFile, nodeCmd.Flags().Lookup(cAPITLSCertFileFlag))
+
+	nodeCmd.Flags().String(cAPITLSKeyFileFlag, config.API.TLSKeyFile, "api TLS key file, reloaded when changed")
+	viper.BindPFlag(cAPITLSKeyFile, nodeCmd.Flags().Lookup(cAPITLSKeyFileFlag))
+
+	nodeCmd.Flags().String(cAPITLSClientCAFileFlag, config.API.TLSClientCAFile, "api CA for client certificates, then required by the admin endpoints")
+	viper.BindPFlag(cAPITLSClientCAFile, nodeCmd.Flags().Lookup(cAPITLSClientCAFileFlag))
+
+	nodeCmd.Flags().StringSlice(cNodeDNSServersFlag, config.Node.DNSServers, "dns server to bootstrap from, can be repeated")
+	viper.BindPFlag(cNodeDNSServers, nodeCmd.Flags().Lookup(cNodeDNSServersFlag))
+
 	nodeCmd.Flags().String(cDNSAddressFlag, config.DNS.Address, "dns address")
 	viper.BindPFlag(cDNSAddressFlag, nodeCmd.Flags().Lookup(cDNSAddressFlag))
 
 	nodeCmd.Flags().Int32(cDNSPortFlag, config.DNS.Port, "dns port")
 	viper.BindPFlag(cDNSPortFlag, nodeCmd.Flags().Lookup(cDNSPortFlag))
 
+	nodeCmd.Flags().String(cDNSSeedDomainFlag, config.DNS.SeedDomain, "domain answered by the dns responder, empty disables it")
+	viper.BindPFlag(cDNSSeedDomain, nodeCmd.Flags().Lookup(cDNSSeedDomainFlag))
+
+	nodeCmd.Flags().Int32(cDNSResponderPortFlag, config.DNS.ResponderPort, "dns responder port")
+	viper.BindPFlag(cDNSResponderPort, nodeCmd.Flags().Lookup(cDNSResponderPortFlag))
+
+	nodeCmd.Flags().String(cDNSTLSCertFileFlag, config.DNS.TLSCertFile, "dns TLS certificate file, reloaded when changed")
+	viper.BindPFlag(cDNSTLSCertFile, nodeCmd.Flags().Lookup(cDNSTLSCertFileFlag))
+
+	nodeCmd.Flags().String(cDNSTLSKeyFileFlag, config.DNS.TLSKeyFile, "dns TLS key file, reloaded when changed")
+	viper.BindPFlag(cDNSTLSKeyFile, nodeCmd.Flags().Lookup(cDNSTLSKeyFileFlag))
+
+	nodeCmd.Flags().String(cDNSTLSClientCAFileFlag, config.DNS.TLSClientCAFile, "dns CA for client certificates, enables the admin endpoints")
+	viper.BindPFlag(cDNSTLSClientCAFile, nodeCmd.Flags().Lookup(cDNSTLSClientCAFileFlag))
+
+	nodeCmd.Flags().String(cLegacyAddressFlag, config.Legacy.Address, "legacy bridge address Pascal nodes connect to")
+	viper.BindPFlag(cLegacyAddress, nodeCmd.Flags().Lookup(cLegacyAddressFlag))
+
+	nodeCmd.Flags().Int32(cLegacyPortFlag, config.Legacy.Port, "legacy bridge port, 0 doesn't listen")
+	viper.BindPFlag(cLegacyPort, nodeCmd.Flags().Lookup(cLegacyPortFlag))
+
+	nodeCmd.Flags().StringSlice(cLegacyNodesFlag, config.Legacy.Nodes, "Pascal node to connect to as host:port, can be repeated")
+	viper.BindPFlag(cLegacyNodes, nodeCmd.Flags().Lookup(cLegacyNodesFlag))
+
 	nodeCmd.Flags().StringVarP(&seed, "seed", "s", "", "seed to connect")
 
 	// Cobra supports local flags which will only run when this command
@@ -225,7 +353,6 @@ func runNode(cmd *cobra.Command, args []string) {
 	// Block here until we receive a termination signal
 	select {
 	case sig := <-sigChan:
-		defer close(quit)
 		// Print a new line after the "^C" or "^\"
 		if sig == syscall.SIGINT || sig == syscall.SIGQUIT || sig == syscall.SIGKILL {
 			fmt.Println()
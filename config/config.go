@@ -25,6 +25,8 @@ const (
 
 	cConfigFolderName  = ".nosogod"
 	cConfigFileName    = "config.toml"
+	cCookieFileName    = ".cookie"
+	cWalletFileName    = "wallet.json"
 	cLogsFolderName    = "logs"
 	cLogLevel          = "info"
 	cLogFileName       = "nosogod.log"
@@ -35,8 +37,24 @@ const (
 	DefaultNodeKey     = "Will be changed upon first run"
 	DefaultAPIAddress  = "0.0.0.0"
 	DefaultAPIPort     = 45505
+	DefaultAPIGRPCPort = 45506
 	DefaultDNSAddress  = "0.0.0.0"
 	DefaultDNSPort     = 8080
+	DefaultDNSDialBack = true
+
+	DefaultDNSResponderAddress = "0.0.0.0"
+	DefaultDNSResponderPort    = 8053
+
+	DefaultDNSReadTimeout    = 10
+	DefaultDNSWriteTimeout   = 10
+	DefaultDNSIdleTimeout    = 60
+	DefaultDNSRateLimit      = 10.0
+	DefaultDNSRateBurst      = 20
+	DefaultDNSMaxConnections = 256
+
+	DefaultLegacyAddress  = "0.0.0.0"
+	DefaultLegacyMaxPeers = 32
+	cLegacyDataFolderName = "legacy"
 )
 
 var (
@@ -58,9 +76,10 @@ var (
 type Config struct {
 	// Top level options use an anonymous struct
 	BaseConfig `mapstructure:",squash"`
-	API        *APIConfig  `mapstructure:"api"`
-	Node       *NodeConfig `mapstructure:"node"`
-	DNS        *DNSConfig  `mapstructure:"dns"`
+	API        *APIConfig    `mapstructure:"api"`
+	Node       *NodeConfig   `mapstructure:"node"`
+	DNS        *DNSConfig    `mapstructure:"dns"`
+	Legacy     *LegacyConfig `mapstructure:"legacy"`
 }
 
 // DefaultConfig Default configurable parameters.
@@ -70,6 +89,7 @@ func DefaultConfig() *Config {
 		API:        DefaultAPIConfig(),
 		Node:       DefaultNodeConfig(),
 		DNS:        DefaultDNSConfig(),
+		Legacy:     DefaultLegacyConfig(),
 	}
 }
 
@@ -89,6 +109,24 @@ func (c *Config) GetConfigFile() string {
 	}
 }
 
+// GetCookieFile returns where the node leaves the API admin cookie
+func (c *Config) GetCookieFile() string {
+	return path.Join(c.GetConfigFolder(), cCookieFileName)
+}
+
+// GetWalletFile returns where the client keeps the encrypted wallet
+func (c *Config) GetWalletFile() string {
+	return path.Join(c.GetConfigFolder(), cWalletFileName)
+}
+
+// GetLegacyDataFolder returns where the legacy bridge keeps the Noso chain files
+func (c *Config) GetLegacyDataFolder() string {
+	if c.Legacy != nil && c.Legacy.DataFolder != "" {
+		return path.Join(c.GetConfigFolder(), c.Legacy.DataFolder)
+	}
+	return path.Join(c.GetConfigFolder(), cLegacyDataFolderName)
+}
+
 func (c *Config) GetLogsFolder() string {
 	if c.ConfigDir != "" && c.LogFolder != "" {
 		return path.Join(c.ConfigDir, c.LogFolder)
@@ -164,12 +202,28 @@ func DefaultBaseConfig() BaseConfig {
 type APIConfig struct {
 	Address string `mapstructure:"address"`
 	Port    int    `mapstructure:"port"`
+	// Port of the gRPC service, on the same address, zero disables it
+	GRPCPort int `mapstructure:"grpc-port"`
+	// TLS certificate and key, reloaded when changed, empty serves plain HTTP
+	TLSCertFile string `mapstructure:"tls-cert-file"`
+	TLSKeyFile  string `mapstructure:"tls-key-file"`
+	// CA verifying client certificates, the admin endpoints then require one
+	TLSClientCAFile string `mapstructure:"tls-client-ca-file"`
+	// Basic auth credentials of the JSON-RPC endpoint, empty disables it
+	RPCUser     string `mapstructure:"rpc-user"`
+	RPCPassword string `mapstructure:"rpc-password"`
+	// Tokens as `role:token`, the role being read or admin
+	Tokens []string `mapstructure:"tokens"`
+	// Lets reads through without a token, admin operations always need one
+	PublicReads bool `mapstructure:"public-reads"`
 }
 
 func DefaultAPIConfig() *APIConfig {
 	return &APIConfig{
-		DefaultAPIAddress,
-		DefaultAPIPort,
+		Address:     DefaultAPIAddress,
+		Port:        DefaultAPIPort,
+		GRPCPort:    DefaultAPIGRPCPort,
+		PublicReads: true,
 	}
 }
 
@@ -179,6 +233,8 @@ type NodeConfig struct {
 	Mode       string `mapstructure:"mode"`
 	PrivateKey string `mapstructure:"private-key"`
 	PublicKey  string `mapstructure:"public-key"`
+	// DNS servers asked for seeds at startup, queried together for failover
+	DNSServers []string `mapstructure:"dns-servers"`
 }
 
 func DefaultNodeConfig() *NodeConfig {
@@ -194,12 +250,66 @@ func DefaultNodeConfig() *NodeConfig {
 type DNSConfig struct {
 	Address string `mapstructure:"address"`
 	Port    int32  `mapstructure:"port"`
+	// Dial back registering peers to confirm they are reachable
+	DialBack bool `mapstructure:"dial-back"`
+	// Domain answered by the DNS responder, empty disables it
+	SeedDomain       string `mapstructure:"seed-domain"`
+	ResponderAddress string `mapstructure:"responder-address"`
+	ResponderPort    int32  `mapstructure:"responder-port"`
+	// HTTP timeouts, in seconds
+	ReadTimeout  int `mapstructure:"read-timeout"`
+	WriteTimeout int `mapstructure:"write-timeout"`
+	IdleTimeout  int `mapstructure:"idle-timeout"`
+	// Requests per second allowed per client IP, zero disables the limit
+	RateLimit float64 `mapstructure:"rate-limit"`
+	RateBurst int     `mapstructure:"rate-burst"`
+	// Maximum concurrent connections, zero disables the limit
+	MaxConnections int `mapstructure:"max-connections"`
+	// TLS certificate and key, reloaded when changed, empty serves plain HTTP
+	TLSCertFile string `mapstructure:"tls-cert-file"`
+	TLSKeyFile  string `mapstructure:"tls-key-file"`
+	// CA verifying client certificates for the admin endpoints
+	TLSClientCAFile string `mapstructure:"tls-client-ca-file"`
 }
 
 func DefaultDNSConfig() *DNSConfig {
 	return &DNSConfig{
-		DefaultDNSAddress,
-		DefaultDNSPort,
-		// "",
+		Address:          DefaultDNSAddress,
+		Port:             DefaultDNSPort,
+		DialBack:         DefaultDNSDialBack,
+		SeedDomain:       "",
+		ResponderAddress: DefaultDNSResponderAddress,
+		ResponderPort:    DefaultDNSResponderPort,
+		ReadTimeout:      DefaultDNSReadTimeout,
+		WriteTimeout:     DefaultDNSWriteTimeout,
+		IdleTimeout:      DefaultDNSIdleTimeout,
+		RateLimit:        DefaultDNSRateLimit,
+		RateBurst:        DefaultDNSRateBurst,
+		MaxConnections:   DefaultDNSMaxConnections,
+	}
+}
+
+type LegacyConfig struct {
+	// Where Pascal nodes connect, a zero port doesn't listen
+	Address string `mapstructure:"address"`
+	Port    int32  `mapstructure:"port"`
+	// Pascal nodes to connect to, as host:port
+	Nodes []string `mapstructure:"nodes"`
+	// Maximum peers, inbound and outbound together, zero disables the limit
+	MaxPeers int `mapstructure:"max-peers"`
+	// Folder of the Noso chain files, relative to the config folder
+	DataFolder string `mapstructure:"data-folder"`
+}
+
+// Enabled tells if the bridge to Pascal nodes has anything to do
+func (c *LegacyConfig) Enabled() bool {
+	return c != nil && (c.Port != 0 || len(c.Nodes) > 0)
+}
+
+func DefaultLegacyConfig() *LegacyConfig {
+	return &LegacyConfig{
+		Address:    DefaultLegacyAddress,
+		MaxPeers:   DefaultLegacyMaxPeers,
+		DataFolder: cLegacyDataFolderName,
 	}
 }
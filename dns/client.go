@@ -0,0 +1,389 @@
+package dns
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+const (
+	cClientTimeout      = 10 * time.Second
+	cClientMaxPages     = 10
+	cClientMaxBodySize  = 4 * 1024 * 1024
+	cClientMaxErrorSize = 1024
+	cClientBackoffBase  = 5 * time.Second
+	cClientBackoffLimit = 5 * time.Minute
+
+	cSeedsPath    = "/v1/seeds"
+	cNodesPath    = "/v1/nodes"
+	cRegisterPath = "/v1/register"
+)
+
+var (
+	ErrNoEndpoints      = errors.New("no dns endpoints given")
+	ErrAllEndpointsDown = errors.New("all dns endpoints failed")
+)
+
+// EndpointHealth is a snapshot of how a DNS endpoint has been answering
+type EndpointHealth struct {
+	URL         string
+	Failures    int
+	LastError   error
+	LastSuccess time.Time
+	Latency     time.Duration
+	RetryAfter  time.Time
+}
+
+// Healthy tells if the endpoint is worth asking right now
+func (h EndpointHealth) Healthy() bool {
+	return time.Now().After(h.RetryAfter)
+}
+
+// Client queries several DNS servers and merges their answers
+type Client struct {
+	mu         sync.Mutex
+	endpoints  []*EndpointHealth
+	httpClient *http.Client
+	cacheDir   string
+}
+
+// NewClient creates a client for the DNS endpoints, caching answers in cacheDir.
+// An empty cacheDir disables the cache.
+func NewClient(endpoints []string, cacheDir string) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: cClientTimeout},
+		cacheDir:   cacheDir,
+	}
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid dns endpoint '%s'", endpoint)
+		}
+		client.endpoints = append(client.endpoints, &EndpointHealth{
+			URL: u.Scheme + "://" + u.Host + strings.TrimSuffix(u.Path, "/"),
+		})
+	}
+
+	return client, nil
+}
+
+// Seeds returns the merged seed lists of the DNS endpoints
+func (c *Client) Seeds(ctx context.Context) (*pb.DNSPeersResponse, error) {
+	return c.query(ctx, cSeedsPath)
+}
+
+// Nodes returns the merged node lists of the DNS endpoints
+func (c *Client) Nodes(ctx context.Context) (*pb.DNSPeersResponse, error) {
+	return c.query(ctx, cNodesPath)
+}
+
+// Register sends a signed registration to every endpoint, succeeding when one accepts it
+func (c *Client) Register(ctx context.Context, req *pb.DNSRegisterRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+		errs     []error
+	)
+	for _, endpoint := range c.allEndpoints() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := c.post(ctx, endpoint+cRegisterPath, body)
+			c.report(endpoint, time.Since(start), err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Debugf("dns endpoint '%s' refused registration: %v", endpoint, err)
+				errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+				return
+			}
+			accepted++
+		}()
+	}
+	wg.Wait()
+
+	if accepted == 0 {
+		return fmt.Errorf("%w: %w", ErrAllEndpointsDown, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Health returns a snapshot of the endpoints' health
+func (c *Client) Health() []EndpointHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	health := make([]EndpointHealth, 0, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		health = append(health, *endpoint)
+	}
+	return health
+}
+
+// Asks all healthy endpoints at once, falling back on the cache when none answer
+func (c *Client) query(ctx context.Context, path string) (*pb.DNSPeersResponse, error) {
+	endpoints := c.healthyEndpoints()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		responses []*pb.DNSPeersResponse
+		errs      []error
+	)
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			msg, err := c.fetchAll(ctx, endpoint+path)
+			c.report(endpoint, time.Since(start), err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Debugf("dns endpoint '%s' failed: %v", endpoint, err)
+				errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+				return
+			}
+			responses = append(responses, msg)
+		}()
+	}
+	wg.Wait()
+
+	if len(responses) == 0 {
+		err := fmt.Errorf("%w: %w", ErrAllEndpointsDown, errors.Join(errs...))
+		if cached, cacheErr := c.loadCache(path); cacheErr == nil {
+			log.Error("using cached dns answer", err)
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	msg := mergePeersResponses(responses)
+	if err := c.saveCache(path, msg); err != nil {
+		log.Error("could not cache dns answer", err)
+	}
+	return msg, nil
+}
+
+// Endpoints not backing off, or all of them when every one is
+func (c *Client) healthyEndpoints() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var healthy, all []string
+	for _, endpoint := range c.endpoints {
+		all = append(all, endpoint.URL)
+		if endpoint.Healthy() {
+			healthy = append(healthy, endpoint.URL)
+		}
+	}
+	if len(healthy) == 0 {
+		return all
+	}
+	return healthy
+}
+
+// Every endpoint, healthy or not
+func (c *Client) allEndpoints() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	all := make([]string, 0, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		all = append(all, endpoint.URL)
+	}
+	return all
+}
+
+// Records the outcome of a request, backing off exponentially on failures
+func (c *Client) report(endpoint string, latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, health := range c.endpoints {
+		if health.URL != endpoint {
+			continue
+		}
+		health.LastError = err
+		if err == nil {
+			health.Failures = 0
+			health.LastSuccess = time.Now()
+			health.Latency = latency
+			health.RetryAfter = time.Time{}
+			return
+		}
+		health.Failures++
+		backoff := min(cClientBackoffBase<<min(health.Failures-1, 16), cClientBackoffLimit)
+		health.RetryAfter = time.Now().Add(backoff)
+		return
+	}
+}
+
+// Follows the cursors until the whole list is fetched
+func (c *Client) fetchAll(ctx context.Context, endpoint string) (*pb.DNSPeersResponse, error) {
+	all := &pb.DNSPeersResponse{}
+	cursor := ""
+	for range cClientMaxPages {
+		page, err := c.fetch(ctx, endpoint, cursor)
+		if err != nil {
+			return nil, err
+		}
+		all.Peers = append(all.Peers, page.Peers...)
+		all.Total = page.Total
+		cursor = page.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	return all, nil
+}
+
+func (c *Client) fetch(ctx context.Context, endpoint string, cursor string) (*pb.DNSPeersResponse, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(pb.MaxPeerQueryLimit))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", pb.ContentTypeProtoBuf)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, cClientMaxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	msg := &pb.DNSPeersResponse{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("could not decode answer: %w", err)
+	}
+	return msg, nil
+}
+
+func (c *Client) post(ctx context.Context, endpoint string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", pb.ContentTypeProtoBuf)
+	req.Header.Set("Accept", pb.ContentTypeProtoBuf)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, cClientMaxErrorSize))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Merges the answers by peer ID, keeping the most recently seen entry
+func mergePeersResponses(responses []*pb.DNSPeersResponse) *pb.DNSPeersResponse {
+	peers := make(map[string]*pb.PeerInfo)
+	for _, response := range responses {
+		for _, peer := range response.Peers {
+			if peer.GetId() == "" {
+				continue
+			}
+			if known, ok := peers[peer.Id]; !ok || peer.LastSeen > known.LastSeen {
+				peers[peer.Id] = peer
+			}
+		}
+	}
+
+	msg := &pb.DNSPeersResponse{}
+	for _, peer := range peers {
+		msg.Peers = append(msg.Peers, peer)
+	}
+	slices.SortFunc(msg.Peers, func(a, b *pb.PeerInfo) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	msg.Total = uint32(len(msg.Peers))
+	msg.Limit = uint32(len(msg.Peers))
+	return msg
+}
+
+func (c *Client) cacheFile(path string) string {
+	return filepath.Join(c.cacheDir, "dns-"+filepath.Base(path)+".pb")
+}
+
+func (c *Client) loadCache(path string) (*pb.DNSPeersResponse, error) {
+	if c.cacheDir == "" {
+		return nil, os.ErrNotExist
+	}
+	data, err := os.ReadFile(c.cacheFile(path))
+	if err != nil {
+		return nil, err
+	}
+	msg := &pb.DNSPeersResponse{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Writes the cache through a temporary file so a crash never leaves it half written
+func (c *Client) saveCache(path string, msg *pb.DNSPeersResponse) error {
+	if c.cacheDir == "" {
+		return nil
+	}
+	if err := utils.EnsureDir(c.cacheDir, 0755); err != nil {
+		return err
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	file := c.cacheFile(path)
+	if err := utils.WriteFile(file+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(file+".tmp", file)
+}
@@ -2,109 +2,101 @@ package dns
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"sync"
+	"time"
 
+	"golang.org/x/net/netutil"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
 	log "github.com/Friends-Of-Noso/NosoGo/logger"
 	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
-)
-
-type (
-	DNSMode int
-)
-
-const (
-	PROTOBUF DNSMode = iota
-	JSON
+	"github.com/Friends-Of-Noso/NosoGo/utils"
 )
 
 type (
 	DNS struct {
-		ctx  context.Context
-		quit *chan struct{}
-		wg   *sync.WaitGroup
+		ctx      context.Context
+		shutdown func()
+		wg       *sync.WaitGroup
 		// cmd        *cobra.Command
 		server     *http.Server
 		dnsAddress string
 		dnsPort    int32
-		mode       DNSMode
+		config     *cfg.DNSConfig
 		nodePeer   *pb.PeerInfo
 		seeds      *pb.PeerList
 		nodes      *pb.PeerList
+		dialBack   DialBackFunc
+		banned     BannedFunc
 	}
 )
 
 func NewDNS(
 	ctx context.Context,
-	quit *chan struct{},
+	shutdown func(),
 	wg *sync.WaitGroup,
 	// cmd *cobra.Command,
 	nodePeer *pb.PeerInfo,
+	seeds *pb.PeerList,
+	nodes *pb.PeerList,
+	dialBack DialBackFunc,
+	banned BannedFunc,
 	address string,
 	port int32,
-	mode DNSMode,
+	config *cfg.DNSConfig,
 ) (*DNS, error) {
 	// Create a new ServeMux
 	mux := http.NewServeMux()
 
 	dns := &DNS{
-		ctx:  ctx,
-		quit: quit,
-		wg:   wg,
+		ctx:      ctx,
+		shutdown: shutdown,
+		wg:       wg,
 		// cmd:        cmd,
 		dnsAddress: address,
 		dnsPort:    port,
+		config:     config,
 		nodePeer:   nodePeer,
-		seeds:      pb.NewPeerList(),
-		nodes:      pb.NewPeerList(),
-		mode:       mode,
+		seeds:      seeds,
+		nodes:      nodes,
+		dialBack:   dialBack,
+		banned:     banned,
 	}
 
-	dns.seeds.Add(&pb.PeerInfo{
-		Address:   "10.42.0.104", // BatchNAS
-		Port:      8080,
-		Id:        "QmUnknown1",
-		Mode:      "seed",
-		Connected: true,
-		Direction: pb.DirectionInbound,
-	})
-
-	dns.nodes.Add(&pb.PeerInfo{
-		Address:   "10.42.0.101", // BatchDev
-		Port:      8080,
-		Id:        "QmUnknown2",
-		Mode:      "node",
-		Connected: true,
-		Direction: pb.DirectionInbound,
-	})
-
-	dns.nodes.Add(&pb.PeerInfo{
-		Address:   "10.42.0.102", // BatchDesk
-		Port:      8080,
-		Id:        "QmUnknown3",
-		Mode:      "node",
-		Connected: true,
-		Direction: pb.DirectionInbound,
-	})
-
 	// Register routes
-	switch dns.mode {
-	case JSON:
-		mux.HandleFunc("/v1/dns", dns.getDNSHandlerJSON)
-		mux.HandleFunc("/v1/seeds", dns.getSeedsHandlerJSON)
-		mux.HandleFunc("/v1/nodes", dns.getNodesHandlerJSON)
-		mux.HandleFunc("/v1/resolve/{ip}", dns.getResolveHandlerJSON)
-	case PROTOBUF:
-		mux.HandleFunc("/v1/dns", dns.getDNSHandlerProtoBuf)
-		mux.HandleFunc("/v1/seeds", dns.getSeedsHandlerProtoBuf)
-		mux.HandleFunc("/v1/nodes", dns.getNodesHandlerProtoBuf)
-		mux.HandleFunc("/v1/resolve/{ip}", dns.getResolveHandlerProtoBuf)
-	}
+	mux.HandleFunc("/v1/dns", dns.getDNSHandler)
+	mux.HandleFunc("/v1/seeds", dns.getSeedsHandler)
+	mux.HandleFunc("/v1/nodes", dns.getNodesHandler)
+	mux.HandleFunc("/v1/resolve/{ip}", dns.getResolveHandler)
+	mux.HandleFunc("/v1/nearest", dns.getNearestHandler)
+	mux.HandleFunc("/v1/register", dns.postRegisterHandler)
 	mux.HandleFunc("/", notFoundHandler)
 
+	tlsConfig, err := utils.NewTLSConfig(config.TLSCertFile, config.TLSKeyFile, config.TLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	// Admin endpoints only exist behind mutual TLS
+	if tlsConfig != nil && tlsConfig.ClientCAs != nil {
+		mux.Handle("/v1/peers/{id}", utils.RequireClientCertificate(http.HandlerFunc(dns.deletePeerHandler)))
+	}
+
+	var handler http.Handler = mux
+	if config.RateLimit > 0 {
+		handler = newIPRateLimiter(config.RateLimit, config.RateBurst).middleware(handler)
+	}
+
 	server := &http.Server{
-		Addr:    address,
-		Handler: mux,
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Duration(config.ReadTimeout) * time.Second,
+		ReadTimeout:       time.Duration(config.ReadTimeout) * time.Second,
+		WriteTimeout:      time.Duration(config.WriteTimeout) * time.Second,
+		IdleTimeout:       time.Duration(config.IdleTimeout) * time.Second,
+		MaxHeaderBytes:    cMaxHeaderBytes,
+		TLSConfig:         tlsConfig,
 	}
 	dns.server = server
 
@@ -115,10 +107,26 @@ func NewDNS(
 func (dns *DNS) Start() {
 	defer dns.wg.Done()
 
-	log.Infof("dns server: Listening on %s", dns.dnsAddress)
-	if err := dns.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Error("ListenAndServe", err)
-		close(*dns.quit)
+	listener, err := net.Listen("tcp", dns.dnsAddress)
+	if err != nil {
+		log.Error("Listen", err)
+		dns.shutdown()
+		return
+	}
+	if dns.config.MaxConnections > 0 {
+		listener = netutil.LimitListener(listener, dns.config.MaxConnections)
+	}
+
+	if dns.server.TLSConfig != nil {
+		log.Infof("dns server: Listening on %s with TLS", dns.dnsAddress)
+		err = dns.server.ServeTLS(listener, "", "")
+	} else {
+		log.Infof("dns server: Listening on %s", dns.dnsAddress)
+		err = dns.server.Serve(listener)
+	}
+	if err != nil && err != http.ErrServerClosed {
+		log.Error("Serve", err)
+		dns.shutdown()
 	}
 }
 
@@ -130,9 +138,10 @@ func (dns *DNS) ShutDown() {
 	}
 }
 
-// func (dns *DNS) GetMode() DNSMode {
-// 	return dns.mode
-// }
+// Returns the HTTP handler of the DNS server
+func (dns *DNS) Handler() http.Handler {
+	return dns.server.Handler
+}
 
 // DNS: Handle 404
 func notFoundHandler(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,149 @@
+package dns
+
+import (
+	"errors"
+	"net/http"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+func (dns *DNS) getDNSHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	peerList := pb.NewPeerList()
+	peerList.Add(dns.nodePeer)
+	pb.WriteNegotiated(w, r, peerList.Response())
+}
+
+func (dns *DNS) getSeedsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("dns serving seeds")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	dns.writePeerQuery(w, r, dns.seeds)
+}
+
+func (dns *DNS) getNodesHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("dns serving nodes")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	dns.writePeerQuery(w, r, dns.nodes)
+}
+
+// Writes the page of the peer list asked for on the URL
+func (dns *DNS) writePeerQuery(w http.ResponseWriter, r *http.Request, peers *pb.PeerList) {
+	q, err := parsePeerQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	msg, err := peers.Query(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pb.WriteNegotiated(w, r, msg)
+}
+
+func (dns *DNS) getResolveHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("dns resolving")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ip := r.PathValue("ip")
+	if ip == "" {
+		http.Error(w, "The IP is empty", http.StatusBadRequest)
+		return
+	}
+	log.Debugf("resolving for '%s'", ip)
+
+	if dns.nodePeer.Address == ip {
+		log.Debug("dns peer found")
+		pb.WriteNegotiated(w, r, dns.nodePeer)
+		return
+	}
+
+	seeds := dns.seeds.Peers()
+	for _, peer := range seeds {
+		if peer.Address == ip {
+			log.Debug("seed peer found")
+			pb.WriteNegotiated(w, r, peer)
+			return
+		}
+	}
+
+	nodes := dns.nodes.Peers()
+	for _, peer := range nodes {
+		if peer.Address == ip {
+			log.Debug("node peer found")
+			pb.WriteNegotiated(w, r, peer)
+			return
+		}
+	}
+
+	log.Debug("no peer found")
+	http.Error(w, "No peer found", http.StatusNotFound)
+}
+
+func (dns *DNS) postRegisterHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("dns registering")
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := &pb.DNSRegisterRequest{}
+	if err := pb.ReadMessage(w, r, cRegisterMaxBodySize, req); err != nil {
+		if errors.Is(err, pb.ErrUnsupportedMediaType) {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		http.Error(w, "Invalid registration", http.StatusBadRequest)
+		return
+	}
+
+	peer, err := dns.register(r.Context(), req, r.RemoteAddr)
+	if err != nil {
+		log.Debugf("registration refused: %v", err)
+		http.Error(w, err.Error(), registerErrorStatus(err))
+		return
+	}
+
+	pb.WriteNegotiated(w, r, peer)
+}
+
+func (dns *DNS) deletePeerHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.PathValue("id")
+	found := false
+	for _, peers := range []*pb.PeerList{dns.seeds, dns.nodes} {
+		if peers.Has(id) {
+			peers.Remove(id)
+			found = true
+		}
+	}
+	if !found {
+		http.Error(w, "No peer found", http.StatusNotFound)
+		return
+	}
+
+	log.Infof("dns removed peer '%s'", id)
+	w.WriteHeader(http.StatusNoContent)
+}
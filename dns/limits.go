@@ -0,0 +1,103 @@
+package dns
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+)
+
+const (
+	cMaxHeaderBytes    = 16 * 1024
+	cLimiterSweepEvery = time.Minute
+	cLimiterIdleAfter  = 10 * time.Minute
+)
+
+// Per client token bucket
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Rate limits requests by client IP
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	rate      rate.Limit
+	burst     int
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+func newIPRateLimiter(requestsPerSecond float64, burst int) *ipRateLimiter {
+	return &ipRateLimiter{
+		rate:      rate.Limit(requestsPerSecond),
+		burst:     max(burst, 1),
+		clients:   make(map[string]*clientLimiter),
+		lastSweep: time.Now(),
+	}
+}
+
+// Returns how long the client has to wait, zero if the request is allowed
+func (l *ipRateLimiter) reserve(ip string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{
+			limiter: rate.NewLimiter(l.rate, l.burst),
+		}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// Forgets clients that have been quiet for a while
+func (l *ipRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < cLimiterSweepEvery {
+		return
+	}
+	l.lastSweep = now
+
+	for ip, client := range l.clients {
+		if now.Sub(client.lastSeen) > cLimiterIdleAfter {
+			delete(l.clients, ip)
+		}
+	}
+}
+
+// Middleware answering 429 to clients over their rate
+func (l *ipRateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		if delay := l.reserve(ip); delay > 0 {
+			log.Debugf("dns rate limiting '%s' for %s", ip, delay)
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, "Too many requests", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,154 @@
+package dns
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cNearestDefaultLimit = 8
+	cNearestMaxLimit     = 32
+	// Peers not seen for longer than this are not handed out
+	cNearestFreshness = 5 * time.Minute
+	// Latencies within the same bucket count as equal, so ties get shuffled
+	cNearestLatencyBucket = 50
+)
+
+// A peer with how close it is to the client
+type nearPeer struct {
+	peer      *pb.PeerInfo
+	proximity int
+	latency   int64
+}
+
+func (dns *DNS) getNearestHandler(w http.ResponseWriter, r *http.Request) {
+	log.Debug("dns serving nearest")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := r.URL.Query()
+
+	peers := dns.seeds
+	switch mode := values.Get(cQueryMode); mode {
+	case "", cfg.NodeModeSeed:
+	case cfg.NodeModeNode:
+		peers = dns.nodes
+	default:
+		http.Error(w, fmt.Sprintf("Unknown mode '%s'", mode), http.StatusBadRequest)
+		return
+	}
+
+	limit := cNearestDefaultLimit
+	if value := values.Get(cQueryLimit); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > cNearestMaxLimit {
+			http.Error(w, fmt.Sprintf("The limit must be between 1 and %d", cNearestMaxLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	nearest, total := nearestPeers(peers.Response().Peers, net.ParseIP(host), limit, time.Now())
+	pb.WriteNegotiated(w, r, &pb.DNSPeersResponse{
+		Peers: nearest,
+		Total: uint32(total),
+		Limit: uint32(limit),
+	})
+}
+
+// Picks up to `limit` healthy peers, closest to the client first, shuffled within
+// equally close peers and shuffled again so clients don't all dial the same one.
+// Returns the picked peers and how many healthy peers there were.
+func nearestPeers(peers []*pb.PeerInfo, client net.IP, limit int, now time.Time) ([]*pb.PeerInfo, int) {
+	freshAfter := now.Add(-cNearestFreshness).Unix()
+
+	candidates := make([]nearPeer, 0, len(peers))
+	for _, peer := range peers {
+		ip := net.ParseIP(peer.Address)
+		if ip == nil || ip.IsUnspecified() {
+			continue
+		}
+		if !peer.Connected && peer.LastSeen < freshAfter {
+			continue
+		}
+		candidates = append(candidates, nearPeer{
+			peer:      peer,
+			proximity: subnetProximity(client, ip),
+			latency:   latencyBucket(peer.LatencyMs),
+		})
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	// Stable, so the shuffle decides among equally near peers
+	slices.SortStableFunc(candidates, func(a, b nearPeer) int {
+		return cmp.Or(
+			cmp.Compare(b.proximity, a.proximity),
+			cmp.Compare(a.latency, b.latency),
+		)
+	})
+
+	picked := make([]*pb.PeerInfo, 0, min(limit, len(candidates)))
+	for _, candidate := range candidates[:min(limit, len(candidates))] {
+		picked = append(picked, candidate.peer)
+	}
+	rand.Shuffle(len(picked), func(i, j int) {
+		picked[i], picked[j] = picked[j], picked[i]
+	})
+
+	return picked, len(candidates)
+}
+
+// Rates how much of a network two addresses share, higher is closer.
+// Without an ASN database the subnet stands in for the provider.
+func subnetProximity(a, b net.IP) int {
+	if a == nil || b == nil {
+		return 0
+	}
+
+	prefixes := []int{48, 32}
+	bits := 128
+	if a4, b4 := a.To4(), b.To4(); a4 != nil || b4 != nil {
+		if a4 == nil || b4 == nil {
+			return 0
+		}
+		a, b = a4, b4
+		prefixes = []int{24, 16}
+		bits = 32
+	}
+
+	for i, prefix := range prefixes {
+		mask := net.CIDRMask(prefix, bits)
+		if a.Mask(mask).Equal(b.Mask(mask)) {
+			return len(prefixes) - i
+		}
+	}
+	return 0
+}
+
+// Unknown latencies sort after all measured ones
+func latencyBucket(latency int64) int64 {
+	if latency <= 0 {
+		return math.MaxInt64
+	}
+	return latency / cNearestLatencyBucket
+}
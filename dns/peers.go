@@ -0,0 +1,51 @@
+package dns
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Returns up to `limit` peers, connected and most recently seen first
+func healthiestPeers(peers map[string]*pb.PeerInfo, limit int) []*pb.PeerInfo {
+	healthiest := make([]*pb.PeerInfo, 0, len(peers))
+	for _, peer := range peers {
+		if net.ParseIP(peer.Address) == nil {
+			continue
+		}
+		healthiest = append(healthiest, peer)
+	}
+
+	slices.SortFunc(healthiest, comparePeerHealth)
+
+	if limit > 0 && len(healthiest) > limit {
+		healthiest = healthiest[:limit]
+	}
+	return healthiest
+}
+
+// Orders peers by connection state, then by last seen time
+func comparePeerHealth(a, b *pb.PeerInfo) int {
+	if a.Connected != b.Connected {
+		if a.Connected {
+			return -1
+		}
+		return 1
+	}
+	if c := cmp.Compare(b.LastSeen, a.LastSeen); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
+
+// Returns the full multiaddr of a peer, including its ID
+func peerMultiaddr(peer *pb.PeerInfo) string {
+	protocol := "ip4"
+	if ip := net.ParseIP(peer.Address); ip != nil && ip.To4() == nil {
+		protocol = "ip6"
+	}
+	return fmt.Sprintf("/%s/%s/tcp/%d/p2p/%s", protocol, peer.Address, peer.Port, peer.Id)
+}
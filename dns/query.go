@@ -0,0 +1,62 @@
+package dns
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Peer list query parameters
+const (
+	cQueryLimit     = "limit"
+	cQueryOffset    = "offset"
+	cQueryCursor    = "cursor"
+	cQueryMode      = "mode"
+	cQueryConnected = "connected"
+	cQueryDirection = "direction"
+	cQueryFamily    = "family"
+	cQuerySort      = "sort"
+	cQueryOrder     = "order"
+)
+
+// Builds a peer query from the URL query parameters
+func parsePeerQuery(values url.Values) (pb.PeerQuery, error) {
+	q := pb.PeerQuery{
+		Mode:      values.Get(cQueryMode),
+		Direction: values.Get(cQueryDirection),
+		Family:    values.Get(cQueryFamily),
+		Sort:      values.Get(cQuerySort),
+		Cursor:    values.Get(cQueryCursor),
+	}
+
+	var err error
+	if value := values.Get(cQueryLimit); value != "" {
+		if q.Limit, err = strconv.Atoi(value); err != nil {
+			return q, fmt.Errorf("%w: invalid limit '%s'", pb.ErrInvalidPeerQuery, value)
+		}
+	}
+	if value := values.Get(cQueryOffset); value != "" {
+		if q.Offset, err = strconv.Atoi(value); err != nil {
+			return q, fmt.Errorf("%w: invalid offset '%s'", pb.ErrInvalidPeerQuery, value)
+		}
+	}
+	if value := values.Get(cQueryConnected); value != "" {
+		connected, err := strconv.ParseBool(value)
+		if err != nil {
+			return q, fmt.Errorf("%w: invalid connected '%s'", pb.ErrInvalidPeerQuery, value)
+		}
+		q.Connected = &connected
+	}
+
+	switch order := values.Get(cQueryOrder); order {
+	case "", "asc":
+	case "desc":
+		q.Descending = true
+	default:
+		return q, fmt.Errorf("%w: unknown order '%s'", pb.ErrInvalidPeerQuery, order)
+	}
+
+	return q, nil
+}
@@ -0,0 +1,167 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cRegisterMaxBodySize = 64 * 1024
+	cRegisterMaxSkew     = 5 * time.Minute
+	cDialBackTimeout     = 10 * time.Second
+)
+
+var (
+	ErrRegisterInvalidPeer      = errors.New("invalid peer")
+	ErrRegisterInvalidTimestamp = errors.New("timestamp is too far from the current time")
+	ErrRegisterInvalidKey       = errors.New("public key does not match the peer ID")
+	ErrRegisterInvalidSignature = errors.New("invalid signature")
+	ErrRegisterUnreachable      = errors.New("peer is unreachable")
+	ErrRegisterBanned           = errors.New("peer is banned")
+)
+
+// DialBackFunc checks that a peer is reachable on the address it registered
+type DialBackFunc func(ctx context.Context, peer *pb.PeerInfo) error
+
+// BannedFunc tells if a peer ID is banned, banned peers can't register
+type BannedFunc func(id string) bool
+
+// NewRegisterRequest creates a registration for the peer signed with the private key
+func NewRegisterRequest(privateKey crypto.PrivKey, peerInfo *pb.PeerInfo) (*pb.DNSRegisterRequest, error) {
+	publicKey, err := crypto.MarshalPublicKey(privateKey.GetPublic())
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal public key: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	signature, err := privateKey.Sign(registerPayload(peerInfo, timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("could not sign registration: %w", err)
+	}
+
+	return &pb.DNSRegisterRequest{
+		Peer:      peerInfo,
+		Timestamp: timestamp,
+		PublicKey: publicKey,
+		Signature: signature,
+	}, nil
+}
+
+// The bytes that get signed on a registration
+func registerPayload(peerInfo *pb.PeerInfo, timestamp int64) []byte {
+	return fmt.Appendf(
+		nil,
+		"nosogo-dns-register:%s:%s:%d:%s:%d",
+		peerInfo.Id,
+		peerInfo.Address,
+		peerInfo.Port,
+		peerInfo.Mode,
+		timestamp,
+	)
+}
+
+// VerifyRegisterRequest checks that the registration was signed by the owner of the peer ID
+func VerifyRegisterRequest(req *pb.DNSRegisterRequest) error {
+	peerInfo := req.GetPeer()
+	if peerInfo == nil || peerInfo.Id == "" || peerInfo.Port <= 0 {
+		return ErrRegisterInvalidPeer
+	}
+	if peerInfo.Mode != cfg.NodeModeSeed && peerInfo.Mode != cfg.NodeModeSuperNode && peerInfo.Mode != cfg.NodeModeNode {
+		return fmt.Errorf("%w: mode '%s' can't be registered", ErrRegisterInvalidPeer, peerInfo.Mode)
+	}
+
+	skew := time.Since(time.Unix(req.Timestamp, 0))
+	if skew > cRegisterMaxSkew || skew < -cRegisterMaxSkew {
+		return ErrRegisterInvalidTimestamp
+	}
+
+	peerID, err := peer.Decode(peerInfo.Id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRegisterInvalidPeer, err)
+	}
+
+	publicKey, err := crypto.UnmarshalPublicKey(req.PublicKey)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRegisterInvalidKey, err)
+	}
+	if !peerID.MatchesPublicKey(publicKey) {
+		return ErrRegisterInvalidKey
+	}
+
+	ok, err := publicKey.Verify(registerPayload(peerInfo, req.Timestamp), req.Signature)
+	if err != nil || !ok {
+		return ErrRegisterInvalidSignature
+	}
+
+	return nil
+}
+
+// Verifies a registration and adds the peer to the matching list
+func (dns *DNS) register(ctx context.Context, req *pb.DNSRegisterRequest, remoteAddress string) (*pb.PeerInfo, error) {
+	if err := VerifyRegisterRequest(req); err != nil {
+		return nil, err
+	}
+	if dns.banned != nil && dns.banned(req.Peer.Id) {
+		return nil, ErrRegisterBanned
+	}
+
+	peerInfo := &pb.PeerInfo{
+		Address: req.Peer.Address,
+		Port:    req.Peer.Port,
+		Id:      req.Peer.Id,
+		Mode:    req.Peer.Mode,
+	}
+
+	// Peers listening on all interfaces can't tell us their address
+	if ip := net.ParseIP(peerInfo.Address); peerInfo.Address == "" || (ip != nil && ip.IsUnspecified()) {
+		if host, _, err := net.SplitHostPort(remoteAddress); err == nil {
+			peerInfo.Address = host
+		}
+	}
+
+	if dns.dialBack != nil {
+		ctx, cancel := context.WithTimeout(ctx, cDialBackTimeout)
+		defer cancel()
+
+		if err := dns.dialBack(ctx, peerInfo); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRegisterUnreachable, err)
+		}
+	}
+	peerInfo.LastSeen = time.Now().Unix()
+
+	if peerInfo.Mode == cfg.NodeModeSeed {
+		dns.nodes.Remove(peerInfo.Id)
+		dns.seeds.Add(peerInfo)
+	} else {
+		dns.seeds.Remove(peerInfo.Id)
+		dns.nodes.Add(peerInfo)
+	}
+	log.Infof("registered %s '%s' at %s:%d", peerInfo.Mode, peerInfo.Id, peerInfo.Address, peerInfo.Port)
+
+	return peerInfo, nil
+}
+
+// Maps a registration error to a HTTP status code
+func registerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrRegisterInvalidKey), errors.Is(err, ErrRegisterInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrRegisterBanned):
+		return http.StatusForbidden
+	case errors.Is(err, ErrRegisterUnreachable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	mdns "github.com/miekg/dns"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cResponderTTL        = 60
+	cResponderMaxAnswers = 8
+	cDNSAddrPrefix       = "_dnsaddr."
+)
+
+// Responder answers standard DNS queries for the seed domain
+type Responder struct {
+	ctx      context.Context
+	shutdown func()
+	wg       *sync.WaitGroup
+	domain   string
+	address  string
+	seeds    *pb.PeerList
+	handler  mdns.Handler
+	servers  []*mdns.Server
+}
+
+// NewResponder creates a UDP and TCP DNS responder for the seed domain
+func NewResponder(
+	ctx context.Context,
+	shutdown func(),
+	wg *sync.WaitGroup,
+	seeds *pb.PeerList,
+	domain string,
+	address string,
+) (*Responder, error) {
+	responder := &Responder{
+		ctx:      ctx,
+		shutdown: shutdown,
+		wg:       wg,
+		domain:   strings.ToLower(mdns.Fqdn(domain)),
+		address:  address,
+		seeds:    seeds,
+	}
+
+	mux := mdns.NewServeMux()
+	mux.HandleFunc(responder.domain, responder.handleSeedDomain)
+	mux.HandleFunc(".", handleRefused)
+	responder.handler = mux
+
+	for _, network := range []string{"udp", "tcp"} {
+		responder.servers = append(responder.servers, &mdns.Server{
+			Addr:    address,
+			Net:     network,
+			Handler: mux,
+		})
+	}
+
+	return responder, nil
+}
+
+// Starts the DNS responder
+func (r *Responder) Start() {
+	defer r.wg.Done()
+
+	log.Infof("dns responder: Serving '%s' on %s", r.domain, r.address)
+
+	var wg sync.WaitGroup
+	for _, server := range r.servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := server.ListenAndServe(); err != nil {
+				log.Errorf("dns responder: ListenAndServe(%s)", err, server.Net)
+				r.shutdown()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Shuts down the DNS responder
+func (r *Responder) ShutDown() {
+	log.Info("dns responder shuting down")
+	for _, server := range r.servers {
+		if err := server.ShutdownContext(r.ctx); err != nil {
+			log.Errorf("dns responder shutdown(%s) failed", err, server.Net)
+		}
+	}
+}
+
+// Returns the handler answering the DNS queries
+func (r *Responder) Handler() mdns.Handler {
+	return r.handler
+}
+
+// Answers A/AAAA with the seeds' addresses and TXT with their multiaddrs
+func (r *Responder) handleSeedDomain(w mdns.ResponseWriter, req *mdns.Msg) {
+	msg := new(mdns.Msg)
+	msg.SetReply(req)
+	msg.Authoritative = true
+
+	if len(req.Question) != 1 {
+		msg.Rcode = mdns.RcodeFormatError
+		w.WriteMsg(msg)
+		return
+	}
+
+	question := req.Question[0]
+	name := strings.ToLower(question.Name)
+	seeds := healthiestPeers(r.seeds.Peers(), cResponderMaxAnswers)
+
+	switch name {
+	case r.domain:
+		for _, seed := range seeds {
+			ip := net.ParseIP(seed.Address)
+			if ip4 := ip.To4(); ip4 != nil && (question.Qtype == mdns.TypeA || question.Qtype == mdns.TypeANY) {
+				msg.Answer = append(msg.Answer, &mdns.A{
+					Hdr: r.header(question.Name, mdns.TypeA),
+					A:   ip4,
+				})
+			}
+			if ip.To4() == nil && (question.Qtype == mdns.TypeAAAA || question.Qtype == mdns.TypeANY) {
+				msg.Answer = append(msg.Answer, &mdns.AAAA{
+					Hdr:  r.header(question.Name, mdns.TypeAAAA),
+					AAAA: ip,
+				})
+			}
+		}
+	case cDNSAddrPrefix + r.domain:
+		if question.Qtype == mdns.TypeTXT || question.Qtype == mdns.TypeANY {
+			for _, seed := range seeds {
+				msg.Answer = append(msg.Answer, &mdns.TXT{
+					Hdr: r.header(question.Name, mdns.TypeTXT),
+					Txt: []string{"dnsaddr=" + peerMultiaddr(seed)},
+				})
+			}
+		}
+	default:
+		msg.Rcode = mdns.RcodeNameError
+	}
+
+	log.Debugf("dns responder: %s %s, %d answers", mdns.TypeToString[question.Qtype], name, len(msg.Answer))
+
+	// Keep UDP answers within what the client can receive
+	if w.LocalAddr().Network() == "udp" {
+		size := mdns.MinMsgSize
+		if opt := req.IsEdns0(); opt != nil {
+			size = int(opt.UDPSize())
+		}
+		msg.Truncate(size)
+	}
+
+	w.WriteMsg(msg)
+}
+
+func (r *Responder) header(name string, rrtype uint16) mdns.RR_Header {
+	return mdns.RR_Header{
+		Name:   name,
+		Rrtype: rrtype,
+		Class:  mdns.ClassINET,
+		Ttl:    cResponderTTL,
+	}
+}
+
+// We are not a recursive resolver
+func handleRefused(w mdns.ResponseWriter, req *mdns.Msg) {
+	msg := new(mdns.Msg)
+	msg.SetRcode(req, mdns.RcodeRefused)
+	w.WriteMsg(msg)
+}
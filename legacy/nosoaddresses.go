@@ -115,7 +115,7 @@ func GenerateNewAddress() (pubKeyStr string, privKeyStr string, address string)
 
 		// Step 2: Serialize as hex
 		pubKeyBytes := pubKey.SerializeUncompressed() // or compressed, depending on chain rules
-		privKeyBytes := privKey.PubKey().SerializeUncompressed()
+		privKeyBytes := privKey.Serialize()
 		pubKeyStr = hex.EncodeToString(pubKeyBytes)
 		privKeyStr = hex.EncodeToString(privKeyBytes)
 
@@ -0,0 +1,343 @@
+package legacy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// A block file, <height>.blk, is a sequence of packed Pascal records, little endian,
+// with Pascal integers being 32 bits:
+//
+//	Header: Number, TimeStart, TimeEnd int64, TimeTotal, TimeLast20, TrxTotales,
+//	Difficult integer, TargetHash String[32], Solution String[200],
+//	LastBlockHash String[32], NxtBlkDiff integer, AccountMiner String[40],
+//	MinerFee, Reward int64
+//	TrxTotales orders: Block integer, OrderID String[64], OrderLines integer,
+//	OrderType String[6], TimeStamp int64, Reference String[64], TrxLine integer,
+//	Sender String[120], Address String[40], Receiver String[40], AmmountFee,
+//	AmmountTrf int64, Signature String[120], TrfrID String[64]
+//	Between PoSBlockStart and PoSBlockEnd: PosReward int64, PosCount integer,
+//	then PosCount addresses as String[32]
+//	From MNBlockStart: MNsReward int64, MNsCount integer, then MNsCount
+//	addresses as String[32]
+const (
+	PoSBlockStart = 8425
+	PoSBlockEnd   = 88500
+	MNBlockStart  = 48010
+
+	cBlockHashSize      = 32
+	cBlockSolutionSize  = 200
+	cBlockAddressSize   = 40
+	cOrderIDSize        = 64
+	cOrderTypeSize      = 6
+	cOrderSenderSize    = 120
+	cOrderSignatureSize = 120
+	cRewardAddressSize  = 32
+)
+
+// A blchhead.nos file is a sequence of packed records: Block integer,
+// BlockHash String[32], SumHash String[32]
+const HeaderEntrySize = 4 + 2*(1+cBlockHashSize)
+
+var (
+	ErrInvalidBlock   = errors.New("invalid legacy block")
+	ErrInvalidHeaders = errors.New("invalid blchhead.nos file")
+)
+
+// Block is the content of a legacy block file
+type Block struct {
+	Number    int64
+	TimeStart int64
+	TimeEnd   int64
+	// Seconds the block took and the average of the last 20
+	TimeTotal  int32
+	TimeLast20 int32
+	Difficult  int32
+	TargetHash string
+	Solution   string
+	// MD5 of the previous block file
+	LastBlockHash string
+	NxtBlkDiff    int32
+	AccountMiner  string
+	// Fees of the orders, paid to the miner along with the reward
+	MinerFee int64
+	Reward   int64
+	Orders   []BlockOrder
+	// Amount paid to every address, PoS blocks only
+	PoSReward    int64
+	PoSAddresses []string
+	// Amount paid to every masternode
+	MNsReward   int64
+	MNAddresses []string
+}
+
+// BlockOrder is a transfer line of an order, as stored in blocks
+type BlockOrder struct {
+	Block      int32
+	OrderID    string
+	OrderLines int32
+	OrderType  string
+	TimeStamp  int64
+	Reference  string
+	TrxLine    int32
+	// Public key of the sender
+	Sender    string
+	Address   string
+	Receiver  string
+	Fee       int64
+	Amount    int64
+	Signature string
+	TrfrID    string
+}
+
+// HasPoS tells if blocks of the height pay proof of stake rewards
+func HasPoS(number int64) bool {
+	return number > PoSBlockStart && number < PoSBlockEnd
+}
+
+// HasMNs tells if blocks of the height pay masternode rewards
+func HasMNs(number int64) bool {
+	return number >= MNBlockStart
+}
+
+// DecodeBlock parses a block file
+func DecodeBlock(data []byte) (*Block, error) {
+	r := &recordReader{data: data}
+	b := &Block{
+		Number:     r.int64(),
+		TimeStart:  r.int64(),
+		TimeEnd:    r.int64(),
+		TimeTotal:  r.int32(),
+		TimeLast20: r.int32(),
+	}
+	orders := r.int32()
+	b.Difficult = r.int32()
+	b.TargetHash = r.shortString(cBlockHashSize)
+	b.Solution = r.shortString(cBlockSolutionSize)
+	b.LastBlockHash = r.shortString(cBlockHashSize)
+	b.NxtBlkDiff = r.int32()
+	b.AccountMiner = r.shortString(cBlockAddressSize)
+	b.MinerFee = r.int64()
+	b.Reward = r.int64()
+	if r.err == nil && (orders < 0 || int(orders) > len(data)) {
+		return nil, fmt.Errorf("%w: %d orders", ErrInvalidBlock, orders)
+	}
+
+	for i := int32(0); i < orders && r.err == nil; i++ {
+		b.Orders = append(b.Orders, BlockOrder{
+			Block:      r.int32(),
+			OrderID:    r.shortString(cOrderIDSize),
+			OrderLines: r.int32(),
+			OrderType:  r.shortString(cOrderTypeSize),
+			TimeStamp:  r.int64(),
+			Reference:  r.shortString(cOrderIDSize),
+			TrxLine:    r.int32(),
+			Sender:     r.shortString(cOrderSenderSize),
+			Address:    r.shortString(cBlockAddressSize),
+			Receiver:   r.shortString(cBlockAddressSize),
+			Fee:        r.int64(),
+			Amount:     r.int64(),
+			Signature:  r.shortString(cOrderSignatureSize),
+			TrfrID:     r.shortString(cOrderIDSize),
+		})
+	}
+
+	if HasPoS(b.Number) {
+		b.PoSReward = r.int64()
+		b.PoSAddresses = r.addresses()
+	}
+	if HasMNs(b.Number) {
+		b.MNsReward = r.int64()
+		b.MNAddresses = r.addresses()
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("%w %d: %v", ErrInvalidBlock, b.Number, r.err)
+	}
+	return b, nil
+}
+
+// EncodeBlock writes a block in the block file format
+func EncodeBlock(b *Block) ([]byte, error) {
+	w := &recordWriter{}
+	w.int64(b.Number)
+	w.int64(b.TimeStart)
+	w.int64(b.TimeEnd)
+	w.int32(b.TimeTotal)
+	w.int32(b.TimeLast20)
+	w.int32(int32(len(b.Orders)))
+	w.int32(b.Difficult)
+	w.shortString(b.TargetHash, cBlockHashSize)
+	w.shortString(b.Solution, cBlockSolutionSize)
+	w.shortString(b.LastBlockHash, cBlockHashSize)
+	w.int32(b.NxtBlkDiff)
+	w.shortString(b.AccountMiner, cBlockAddressSize)
+	w.int64(b.MinerFee)
+	w.int64(b.Reward)
+	for _, o := range b.Orders {
+		w.int32(o.Block)
+		w.shortString(o.OrderID, cOrderIDSize)
+		w.int32(o.OrderLines)
+		w.shortString(o.OrderType, cOrderTypeSize)
+		w.int64(o.TimeStamp)
+		w.shortString(o.Reference, cOrderIDSize)
+		w.int32(o.TrxLine)
+		w.shortString(o.Sender, cOrderSenderSize)
+		w.shortString(o.Address, cBlockAddressSize)
+		w.shortString(o.Receiver, cBlockAddressSize)
+		w.int64(o.Fee)
+		w.int64(o.Amount)
+		w.shortString(o.Signature, cOrderSignatureSize)
+		w.shortString(o.TrfrID, cOrderIDSize)
+	}
+
+	if HasPoS(b.Number) {
+		w.int64(b.PoSReward)
+		w.addresses(b.PoSAddresses)
+	}
+	if HasMNs(b.Number) {
+		w.int64(b.MNsReward)
+		w.addresses(b.MNAddresses)
+	}
+	if w.err != nil {
+		return nil, fmt.Errorf("%w %d: %v", ErrInvalidBlock, b.Number, w.err)
+	}
+	return w.buf.Bytes(), nil
+}
+
+// HeaderEntry is the record of a block in the headers file
+type HeaderEntry struct {
+	Block int32
+	// MD5 of the block file
+	BlockHash string
+	// MD5 of the summary after the block
+	SummaryHash string
+}
+
+// DecodeHeaders parses a blchhead.nos file
+func DecodeHeaders(data []byte) ([]HeaderEntry, error) {
+	if len(data)%HeaderEntrySize != 0 {
+		return nil, fmt.Errorf("%w: size %d is not a multiple of %d", ErrInvalidHeaders, len(data), HeaderEntrySize)
+	}
+
+	r := &recordReader{data: data}
+	entries := make([]HeaderEntry, 0, len(data)/HeaderEntrySize)
+	for r.offset < len(data) && r.err == nil {
+		entries = append(entries, HeaderEntry{
+			Block:       r.int32(),
+			BlockHash:   r.shortString(cBlockHashSize),
+			SummaryHash: r.shortString(cBlockHashSize),
+		})
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeaders, r.err)
+	}
+	return entries, nil
+}
+
+// EncodeHeaders writes entries in the blchhead.nos format
+func EncodeHeaders(entries []HeaderEntry) ([]byte, error) {
+	w := &recordWriter{}
+	for _, entry := range entries {
+		w.int32(entry.Block)
+		w.shortString(entry.BlockHash, cBlockHashSize)
+		w.shortString(entry.SummaryHash, cBlockHashSize)
+	}
+	if w.err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeaders, w.err)
+	}
+	return w.buf.Bytes(), nil
+}
+
+// Reads packed records, keeping the first error so fields can be read in a row
+type recordReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *recordReader) next(size int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.offset+size > len(r.data) {
+		r.err = fmt.Errorf("truncated at byte %d", r.offset)
+		return nil
+	}
+	field := r.data[r.offset : r.offset+size]
+	r.offset += size
+	return field
+}
+
+func (r *recordReader) int32() int32 {
+	if field := r.next(4); field != nil {
+		return int32(binary.LittleEndian.Uint32(field))
+	}
+	return 0
+}
+
+func (r *recordReader) int64() int64 {
+	if field := r.next(8); field != nil {
+		return int64(binary.LittleEndian.Uint64(field))
+	}
+	return 0
+}
+
+func (r *recordReader) shortString(size int) string {
+	field := r.next(1 + size)
+	if field == nil {
+		return ""
+	}
+	if int(field[0]) > size {
+		r.err = fmt.Errorf("string of length %d over %d at byte %d", field[0], size, r.offset-1-size)
+		return ""
+	}
+	return string(field[1 : 1+field[0]])
+}
+
+// Reads a count of reward addresses, then the addresses
+func (r *recordReader) addresses() []string {
+	count := r.int32()
+	if r.err == nil && (count < 0 || int(count)*(1+cRewardAddressSize) > len(r.data)-r.offset) {
+		r.err = fmt.Errorf("%d addresses at byte %d", count, r.offset-4)
+	}
+	var addresses []string
+	for i := int32(0); i < count && r.err == nil; i++ {
+		addresses = append(addresses, r.shortString(cRewardAddressSize))
+	}
+	return addresses
+}
+
+// Writes packed records, keeping the first error
+type recordWriter struct {
+	buf bytes.Buffer
+	err error
+}
+
+func (w *recordWriter) int32(value int32) {
+	w.buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(value)))
+}
+
+func (w *recordWriter) int64(value int64) {
+	w.buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(value)))
+}
+
+func (w *recordWriter) shortString(value string, size int) {
+	if len(value) > size {
+		if w.err == nil {
+			w.err = fmt.Errorf("'%s' is longer than %d characters", value, size)
+		}
+		return
+	}
+	w.buf.WriteByte(byte(len(value)))
+	w.buf.WriteString(value)
+	w.buf.Write(make([]byte, size-len(value)))
+}
+
+func (w *recordWriter) addresses(addresses []string) {
+	w.int32(int32(len(addresses)))
+	for _, address := range addresses {
+		w.shortString(address, cRewardAddressSize)
+	}
+}
@@ -0,0 +1,63 @@
+package legacy
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// SignMessage signs the SHA256 of a message with a SECP256k1 private key,
+// returning the base64 DER signature
+func SignMessage(privateKey string, message string) (string, error) {
+	keyBytes, err := decodeKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(keyBytes)
+
+	digest := sha256.Sum256([]byte(message))
+	signature := ecdsa.Sign(privKey, digest[:])
+	return base64.StdEncoding.EncodeToString(signature.Serialize()), nil
+}
+
+// VerifyMessage checks a base64 DER signature of a message against a SECP256k1 public key
+func VerifyMessage(publicKey string, message string, signature string) bool {
+	keyBytes, err := decodeKey(publicKey)
+	if err != nil {
+		return false
+	}
+	pubKey, err := btcec.ParsePubKey(keyBytes)
+	if err != nil {
+		return false
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	sig, err := ecdsa.ParseDERSignature(sigBytes)
+	if err != nil {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(message))
+	return sig.Verify(digest[:], pubKey)
+}
+
+// IsAddressOfPublicKey tells if an address, of either type, belongs to the public key
+func IsAddressOfPublicKey(address string, publicKey string) bool {
+	return address == GetAddressFromPublicKey(publicKey, 0) ||
+		address == GetAddressFromPublicKey(publicKey, 1)
+}
+
+// Keys come hex encoded from GenerateNewAddress and base64 encoded from Noso wallets
+func decodeKey(key string) ([]byte, error) {
+	if keyBytes, err := hex.DecodeString(key); err == nil {
+		return keyBytes, nil
+	}
+	return base64.StdEncoding.DecodeString(key)
+}
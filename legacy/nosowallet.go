@@ -0,0 +1,150 @@
+package legacy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// A wallet.pkw file is a sequence of packed Pascal records:
+// Hash String[40], Custom String[40], PublicKey String[255], PrivateKey String[255],
+// then Balance, Pending, Score and LastOP as little endian int64.
+// Short strings are a length byte followed by their maximum length in bytes.
+const (
+	cWalletHashSize = 40
+	cWalletKeySize  = 255
+
+	WalletEntrySize = 2*(1+cWalletHashSize) + 2*(1+cWalletKeySize) + 4*8
+)
+
+var ErrInvalidWallet = errors.New("invalid wallet.pkw file")
+
+// WalletEntry is an address of a legacy Noso wallet
+type WalletEntry struct {
+	// Address
+	Hash string
+	// Alias registered on chain, if any
+	Custom     string
+	PublicKey  string
+	PrivateKey string
+	// Last known balance and pending payments, refreshed by the wallet
+	Balance int64
+	Pending int64
+	Score   int64
+	// Unix time of the last operation
+	LastOP int64
+}
+
+// Verify tells if the address is the one of the public key
+func (e *WalletEntry) Verify() error {
+	if e.Hash != GetAddressFromPublicKey(e.PublicKey, 0) && e.Hash != GetAddressFromPublicKey(e.PublicKey, 1) {
+		return fmt.Errorf("%w: '%s' is not the address of its public key", ErrInvalidWallet, e.Hash)
+	}
+	return nil
+}
+
+// DecodeWallet parses the entries of a wallet.pkw file
+func DecodeWallet(data []byte) ([]WalletEntry, error) {
+	if len(data)%WalletEntrySize != 0 {
+		return nil, fmt.Errorf("%w: size %d is not a multiple of %d", ErrInvalidWallet, len(data), WalletEntrySize)
+	}
+
+	entries := make([]WalletEntry, 0, len(data)/WalletEntrySize)
+	for offset := 0; offset < len(data); offset += WalletEntrySize {
+		record := data[offset : offset+WalletEntrySize]
+		var entry WalletEntry
+		var err error
+		if entry.Hash, record, err = readShortString(record, cWalletHashSize); err != nil {
+			return nil, err
+		}
+		if entry.Custom, record, err = readShortString(record, cWalletHashSize); err != nil {
+			return nil, err
+		}
+		if entry.PublicKey, record, err = readShortString(record, cWalletKeySize); err != nil {
+			return nil, err
+		}
+		if entry.PrivateKey, record, err = readShortString(record, cWalletKeySize); err != nil {
+			return nil, err
+		}
+		entry.Balance = int64(binary.LittleEndian.Uint64(record[0:]))
+		entry.Pending = int64(binary.LittleEndian.Uint64(record[8:]))
+		entry.Score = int64(binary.LittleEndian.Uint64(record[16:]))
+		entry.LastOP = int64(binary.LittleEndian.Uint64(record[24:]))
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// EncodeWallet writes entries in the wallet.pkw format
+func EncodeWallet(entries []WalletEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(len(entries) * WalletEntrySize)
+	for _, entry := range entries {
+		for _, field := range []struct {
+			value string
+			size  int
+		}{
+			{entry.Hash, cWalletHashSize},
+			{entry.Custom, cWalletHashSize},
+			{entry.PublicKey, cWalletKeySize},
+			{entry.PrivateKey, cWalletKeySize},
+		} {
+			if len(field.value) > field.size {
+				return nil, fmt.Errorf("%w: '%s' is longer than %d characters", ErrInvalidWallet, field.value, field.size)
+			}
+			buf.WriteByte(byte(len(field.value)))
+			buf.WriteString(field.value)
+			buf.Write(make([]byte, field.size-len(field.value)))
+		}
+		for _, value := range []int64{entry.Balance, entry.Pending, entry.Score, entry.LastOP} {
+			buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(value)))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadWalletFile reads a wallet.pkw file, verifying the address of every entry
+func ReadWalletFile(path string) ([]WalletEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := DecodeWallet(data)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if err := entries[i].Verify(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// WriteWalletFile writes a wallet.pkw file that must not exist yet
+func WriteWalletFile(path string, entries []WalletEntry) error {
+	data, err := EncodeWallet(entries)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Reads a Pascal short string of the given maximum length, returning the rest
+func readShortString(data []byte, size int) (string, []byte, error) {
+	length := int(data[0])
+	if length > size {
+		return "", nil, fmt.Errorf("%w: string of length %d over %d", ErrInvalidWallet, length, size)
+	}
+	return string(data[1 : 1+length]), data[1+size:], nil
+}
@@ -0,0 +1,79 @@
+package legacyimport
+
+import (
+	"fmt"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Converts a legacy block, hashed as its file, with a transaction per order line
+// and per reward
+func convertBlock(block *legacy.Block, hash string) (*pb.Block, []*pb.Transaction, error) {
+	height := uint64(block.Number)
+	newBlock := &pb.Block{
+		Height:       height,
+		Hash:         hash,
+		PreviousHash: block.LastBlockHash,
+		Timestamp:    block.TimeEnd,
+	}
+	// The genesis block has no previous block, only a seed in its place
+	if height == 0 {
+		newBlock.PreviousHash = ""
+	}
+
+	var transactions []*pb.Transaction
+	for _, order := range block.Orders {
+		if order.Amount < 0 || order.Fee < 0 {
+			return nil, nil, fmt.Errorf("%w %d: order '%s' amounts", legacy.ErrInvalidBlock, height, order.OrderID)
+		}
+		transaction := &pb.Transaction{
+			Hash:        order.TrfrID,
+			BlockHeight: height,
+			Type:        order.OrderType,
+			Timestamp:   order.TimeStamp,
+			Amount:      uint64(order.Amount),
+			Fee:         uint64(order.Fee),
+			PubKey:      order.Sender,
+			Verify:      order.Signature,
+			Sender:      order.Address,
+			Receiver:    order.Receiver,
+		}
+		// Transfers are known by their id on the legacy chain
+		if transaction.Hash == "" {
+			if err := transaction.SetHash(); err != nil {
+				return nil, nil, err
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	rewards := []struct {
+		kind      string
+		amount    int64
+		addresses []string
+	}{
+		{pb.TransactionTypeCoinbase, block.Reward + block.MinerFee, []string{block.AccountMiner}},
+		{pb.TransactionTypePoS, block.PoSReward, block.PoSAddresses},
+		{pb.TransactionTypeMN, block.MNsReward, block.MNAddresses},
+	}
+	for _, reward := range rewards {
+		if reward.amount < 0 {
+			return nil, nil, fmt.Errorf("%w %d: %s reward of %d", legacy.ErrInvalidBlock, height, reward.kind, reward.amount)
+		}
+		for _, address := range reward.addresses {
+			transaction := &pb.Transaction{
+				BlockHeight: height,
+				Type:        reward.kind,
+				Timestamp:   block.TimeEnd,
+				Amount:      uint64(reward.amount),
+				Receiver:    address,
+			}
+			if err := transaction.SetHash(); err != nil {
+				return nil, nil, err
+			}
+			transactions = append(transactions, transaction)
+		}
+	}
+	return newBlock, transactions, nil
+}
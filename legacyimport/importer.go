@@ -0,0 +1,229 @@
+package legacyimport
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	"github.com/Friends-Of-Noso/NosoGo/legacynet"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+// Blocks stored in one write, with the checkpoint
+const DefaultBatchSize = 1000
+
+var (
+	// ErrChainExists is returned when the database holds a chain that wasn't imported
+	ErrChainExists = errors.New("database already holds a chain")
+	// ErrChainMismatch is returned when a block doesn't follow the previous one
+	// or its headers entry
+	ErrChainMismatch = errors.New("legacy chain mismatch")
+)
+
+// Result tells what an import stored
+type Result struct {
+	// Blocks stored, from First to Last, none when Blocks is zero
+	First        uint64
+	Last         uint64
+	Blocks       uint64
+	Transactions uint64
+}
+
+// Importer stores the blocks of a legacy NOSODATA folder, in height order.
+// Every batch records the last block stored so an import resumes where it stopped.
+type Importer struct {
+	sm        *store.StorageManager
+	folder    string
+	batchSize int
+	// Called after every batch written
+	progress func(last uint64)
+}
+
+// NewImporter creates an importer of the NOSODATA folder, holding BLOCKS and blchhead.nos
+func NewImporter(sm *store.StorageManager, folder string, batchSize int, progress func(last uint64)) *Importer {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Importer{
+		sm:        sm,
+		folder:    folder,
+		batchSize: batchSize,
+		progress:  progress,
+	}
+}
+
+// Checkpoint returns the last block imported, nil before the first import
+func (i *Importer) Checkpoint() (*pb.Status, error) {
+	checkpoint := &pb.Status{}
+	err := i.sm.StatusStorage().Get(pb.LegacyImportKey, checkpoint)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Import stores the blocks after the checkpoint up to the first missing block file.
+// On errors or once cancelled, it writes the blocks read so far before returning.
+func (i *Importer) Import(ctx context.Context) (Result, error) {
+	var result Result
+
+	checkpoint, err := i.Checkpoint()
+	if err != nil {
+		return result, err
+	}
+	height, previousHash := uint64(0), ""
+	if checkpoint != nil {
+		height, previousHash = checkpoint.LastBlock+1, checkpoint.LastHash
+	} else if err := i.checkEmpty(); err != nil {
+		return result, err
+	}
+
+	headers, err := i.readHeaders()
+	if err != nil {
+		return result, err
+	}
+
+	batch := i.sm.NewBlockBatch()
+	var readErr error
+	for ; ctx.Err() == nil; height++ {
+		newBlock, transactions, err := i.readBlock(height, previousHash, headers)
+		if err != nil {
+			readErr = err
+			break
+		}
+		if newBlock == nil {
+			break
+		}
+		if err := batch.PutBlock(newBlock, transactions); err != nil {
+			return result, err
+		}
+		if result.Blocks == 0 {
+			result.First = height
+		}
+		result.Last = height
+		result.Blocks++
+		result.Transactions += uint64(len(transactions))
+		previousHash = newBlock.Hash
+
+		if batch.Blocks() >= i.batchSize {
+			if err := i.write(batch, height, previousHash); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	// The blocks read before an error are fine, the next import resumes after them
+	if batch.Blocks() > 0 {
+		if err := i.write(batch, result.Last, previousHash); err != nil {
+			return result, err
+		}
+	}
+	if readErr != nil {
+		return result, readErr
+	}
+	return result, ctx.Err()
+}
+
+// Reads and converts a block, checking it follows the previous one and matches
+// its headers entry. Returns nil when there is no file for the height.
+func (i *Importer) readBlock(height uint64, previousHash string, headers map[uint64]string) (*pb.Block, []*pb.Transaction, error) {
+	path := filepath.Join(i.folder, legacynet.BlocksFolderName, strconv.FormatUint(height, 10)+legacynet.BlockFileExt)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sum := md5.Sum(data)
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if expected := headers[height]; expected != "" && !strings.EqualFold(expected, hash) {
+		return nil, nil, fmt.Errorf("%w: block %d hash %s, headers have %s", ErrChainMismatch, height, hash, expected)
+	}
+	block, err := legacy.DecodeBlock(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if block.Number != int64(height) {
+		return nil, nil, fmt.Errorf("%w: file of block %d holds block %d", ErrChainMismatch, height, block.Number)
+	}
+	if height > 0 && !strings.EqualFold(block.LastBlockHash, previousHash) {
+		return nil, nil, fmt.Errorf("%w: block %d follows %s, not %s", ErrChainMismatch, height, block.LastBlockHash, previousHash)
+	}
+	return convertBlock(block, hash)
+}
+
+// Writes a batch along with the checkpoint and the status of the chain
+func (i *Importer) write(batch *store.BlockBatch, last uint64, hash string) error {
+	status := &pb.Status{LastBlock: last, LastHash: hash}
+	if err := batch.PutStatus(pb.LegacyImportKey, status); err != nil {
+		return err
+	}
+	if err := batch.PutStatus(pb.StatusKey, status); err != nil {
+		return err
+	}
+	// The chain is only ever written here, indexed
+	if err := batch.PutIndexesVersion(); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if i.progress != nil {
+		i.progress(last)
+	}
+	return nil
+}
+
+// Without a checkpoint, the database may only hold block zero
+func (i *Importer) checkEmpty() error {
+	status := &pb.Status{}
+	err := i.sm.StatusStorage().Get(pb.StatusKey, status)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if status.LastBlock > 0 {
+		return fmt.Errorf("%w up to block %d", ErrChainExists, status.LastBlock)
+	}
+	return nil
+}
+
+// Reads the block hashes of the headers file, by height, none when there is no file
+func (i *Importer) readHeaders() (map[uint64]string, error) {
+	data, err := os.ReadFile(filepath.Join(i.folder, legacynet.HeadersFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries, err := legacy.DecodeHeaders(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// A map, as entries may name any block
+	hashes := make(map[uint64]string, len(entries))
+	for _, entry := range entries {
+		if entry.Block < 0 {
+			return nil, fmt.Errorf("%w: block %d", legacy.ErrInvalidHeaders, entry.Block)
+		}
+		hashes[uint64(entry.Block)] = entry.BlockHash
+	}
+	return hashes, nil
+}
@@ -0,0 +1,621 @@
+package legacynet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+)
+
+const (
+	cHandshakeTimeout = 10 * time.Second
+	cDialTimeout      = 10 * time.Second
+	// Peers ping every few seconds, a silent one is gone
+	cPeerTimeout = 60 * time.Second
+	cFileTimeout = 2 * time.Minute
+	// Pings, dials and sync requests go on every tick
+	cTickInterval = 10 * time.Second
+	// How long a request for files may go unanswered before asking again
+	cSyncTimeout = time.Minute
+	// Orders are remembered this long so relaying them doesn't loop
+	cOrderMemory = 10 * time.Minute
+
+	// Connection status of the node, as Pascal nodes report it
+	cConStatusConnected = 2
+	cConStatusSynced    = 3
+)
+
+// OrderHandler gets the orders coming from Pascal nodes, as ORDER lines
+type OrderHandler func(line string)
+
+// Bridge connects to Pascal nodes over the legacy protocol. It follows the
+// legacy chain into the data folder and relays orders between Pascal nodes
+// and the other bridges. The chain it follows only goes to the data folder,
+// `nosogod import-legacy` stores it, and NosoGo transactions aren't turned
+// into orders, Pascal nodes couldn't verify their signatures.
+type Bridge struct {
+	ctx      context.Context
+	shutdown func()
+	wg       *sync.WaitGroup
+	address  string
+	config   *cfg.LegacyConfig
+	chain    *chain
+	onOrder  OrderHandler
+	listener net.Listener
+
+	mu        sync.Mutex
+	peers     map[string]*Peer
+	dialing   map[string]bool
+	orders    map[string]time.Time
+	syncUntil time.Time
+	// The peer asked by the pending sync request and the file answering it
+	asked        *Peer
+	askedFile    string
+	headersAsked string
+	mnsAsked     string
+}
+
+// NewBridge creates a bridge listening on the address when the port is set,
+// and keeping the chain files in the data folder
+func NewBridge(
+	ctx context.Context,
+	shutdown func(),
+	wg *sync.WaitGroup,
+	address string,
+	config *cfg.LegacyConfig,
+	dataFolder string,
+	onOrder OrderHandler,
+) (*Bridge, error) {
+	chain, err := openChain(dataFolder)
+	if err != nil {
+		return nil, fmt.Errorf("could not open legacy data folder: %w", err)
+	}
+
+	return &Bridge{
+		ctx:      ctx,
+		shutdown: shutdown,
+		wg:       wg,
+		address:  address,
+		config:   config,
+		chain:    chain,
+		onOrder:  onOrder,
+		peers:    make(map[string]*Peer),
+		dialing:  make(map[string]bool),
+		orders:   make(map[string]time.Time),
+	}, nil
+}
+
+// Starts the bridge
+func (b *Bridge) Start() {
+	defer b.wg.Done()
+
+	if b.config.Port != 0 {
+		listener, err := net.Listen("tcp", b.address)
+		if err != nil {
+			log.Error("legacy bridge: Listen", err)
+			b.shutdown()
+			return
+		}
+		b.listener = listener
+		log.Infof("legacy bridge: Listening on %s", b.address)
+
+		b.wg.Add(1)
+		go b.accept()
+	}
+
+	ticker := time.NewTicker(cTickInterval)
+	defer ticker.Stop()
+	for {
+		b.dialNodes()
+		b.ping()
+		b.sync()
+
+		select {
+		case <-b.ctx.Done():
+			log.Debug("legacy bridge exiting")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shuts down the bridge, closing every connection
+func (b *Bridge) ShutDown() {
+	log.Info("legacy bridge shutting down")
+	if b.listener != nil {
+		b.listener.Close()
+	}
+	for _, peer := range b.Peers() {
+		peer.Close()
+	}
+}
+
+// Peers returns the connected Pascal nodes
+func (b *Bridge) Peers() []*Peer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	peers := make([]*Peer, 0, len(b.peers))
+	for _, peer := range b.peers {
+		peers = append(peers, peer)
+	}
+	slices.SortFunc(peers, func(a, b *Peer) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return peers
+}
+
+// LastBlock returns the last legacy block followed, false when there are none yet
+func (b *Bridge) LastBlock() (uint64, bool) {
+	return b.chain.last()
+}
+
+// Consensus returns the status most peers agree on, nil before any ping
+func (b *Bridge) Consensus() *NodeStatus {
+	votes := make(map[string]int)
+	var best *NodeStatus
+	bestVotes := 0
+	for _, peer := range b.Peers() {
+		status := peer.Status()
+		if status == nil {
+			continue
+		}
+		key := strconv.FormatUint(status.LastBlock, 10) + " " + status.LastHash
+		votes[key]++
+		if votes[key] > bestVotes || (votes[key] == bestVotes && status.LastBlock > best.LastBlock) {
+			best, bestVotes = status, votes[key]
+		}
+	}
+	return best
+}
+
+// RelayOrder sends an ORDER line from the NosoGo network to the Pascal nodes
+func (b *Bridge) RelayOrder(line string) error {
+	msg, err := ParseMessage(line)
+	if err != nil {
+		return err
+	}
+	if msg.Command != CmdOrder {
+		return fmt.Errorf("%w: '%s' is not an order", ErrInvalidMessage, msg.Command)
+	}
+	order, err := ParseOrder(msg.Args)
+	if err != nil {
+		return err
+	}
+	if err := order.Validate(); err != nil {
+		return err
+	}
+	if !b.rememberOrder(order.ID()) {
+		return nil
+	}
+
+	b.broadcast(NewMessage(CmdOrder, order.Args()...), nil)
+	return nil
+}
+
+// Accepts Pascal nodes connecting to us
+func (b *Bridge) accept() {
+	defer b.wg.Done()
+
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) || b.ctx.Err() != nil {
+				return
+			}
+			log.Error("legacy bridge: Accept", err)
+			continue
+		}
+
+		b.wg.Add(1)
+		go b.serveInbound(conn)
+	}
+}
+
+// Waits for the handshake of an inbound connection
+func (b *Bridge) serveInbound(conn net.Conn) {
+	peer := newPeer(conn, conn.RemoteAddr().String(), true)
+
+	line, err := peer.readLine(cHandshakeTimeout)
+	if err != nil {
+		log.Debugf("legacy bridge: no handshake from %s: %v", peer.name, err)
+		peer.Close()
+		b.wg.Done()
+		return
+	}
+	msg, err := ParseMessage(line)
+	if err != nil {
+		peer.Close()
+		b.wg.Done()
+		return
+	}
+
+	switch {
+	case msg.Command == CmdNodeStatus:
+		// Wallets ask for the status and hang up
+		if err := peer.Send(&Message{Command: CmdNodeStatus, Args: b.status().Args()}); err != nil {
+			log.Debugf("legacy bridge: could not send status to %s: %v", peer.name, err)
+		}
+		peer.Close()
+		b.wg.Done()
+	case msg.Version != "" && msg.Command == "":
+		b.run(peer)
+	default:
+		log.Debugf("legacy bridge: unexpected first line from %s: '%s'", peer.name, msg.Command)
+		peer.Close()
+		b.wg.Done()
+	}
+}
+
+// Connects to the configured nodes we aren't connected to
+func (b *Bridge) dialNodes() {
+	for _, address := range b.config.Nodes {
+		b.mu.Lock()
+		_, connected := b.peers[address]
+		skip := connected || b.dialing[address]
+		if !skip {
+			b.dialing[address] = true
+		}
+		b.mu.Unlock()
+		if skip {
+			continue
+		}
+
+		b.wg.Add(1)
+		go b.dial(address)
+	}
+}
+
+// Connects to a node and runs it, still dialing until it goes away
+func (b *Bridge) dial(address string) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.dialing, address)
+		b.mu.Unlock()
+	}()
+
+	dialer := net.Dialer{Timeout: cDialTimeout}
+	conn, err := dialer.DialContext(b.ctx, "tcp", address)
+	if err != nil {
+		log.Debugf("legacy bridge: could not connect to %s: %v", address, err)
+		b.wg.Done()
+		return
+	}
+
+	peer := newPeer(conn, address, false)
+	host, _, _ := net.SplitHostPort(address)
+	handshake := fmt.Sprintf("%s %s %s %d\r\n", cHeaderTag, host, ProgramVersion, time.Now().Unix())
+	if err := peer.write([]byte(handshake)); err != nil {
+		log.Debugf("legacy bridge: handshake with %s failed: %v", address, err)
+		peer.Close()
+		b.wg.Done()
+		return
+	}
+	if err := peer.Send(NewMessage(CmdPing, b.status().Args()...)); err != nil {
+		peer.Close()
+		b.wg.Done()
+		return
+	}
+
+	b.run(peer)
+}
+
+// Reads and handles the lines of a peer until it goes away
+func (b *Bridge) run(peer *Peer) {
+	defer b.wg.Done()
+
+	b.mu.Lock()
+	if _, exists := b.peers[peer.name]; exists || (b.config.MaxPeers > 0 && len(b.peers) >= b.config.MaxPeers) {
+		b.mu.Unlock()
+		log.Debugf("legacy bridge: dropping %s, already connected or too many peers", peer.name)
+		peer.Close()
+		return
+	}
+	b.peers[peer.name] = peer
+	b.mu.Unlock()
+	log.Infof("legacy bridge: connected to %s", peer.name)
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.peers, peer.name)
+		b.mu.Unlock()
+		peer.Close()
+		log.Infof("legacy bridge: disconnected from %s", peer.name)
+	}()
+
+	for {
+		line, err := peer.readLine(cPeerTimeout)
+		if err != nil {
+			if b.ctx.Err() == nil {
+				log.Debugf("legacy bridge: reading from %s: %v", peer.name, err)
+			}
+			return
+		}
+		msg, err := ParseMessage(line)
+		if err != nil {
+			log.Debugf("legacy bridge: from %s: %v", peer.name, err)
+			continue
+		}
+		if err := b.handle(peer, msg); err != nil {
+			log.Debugf("legacy bridge: handling '%s' from %s: %v", msg.Command, peer.name, err)
+			return
+		}
+	}
+}
+
+// Handles a message, an error drops the peer
+func (b *Bridge) handle(peer *Peer, msg *Message) error {
+	switch msg.Command {
+	case CmdPing, CmdPong:
+		status, err := ParseNodeStatus(msg.Args)
+		if err != nil {
+			return err
+		}
+		peer.setStatus(status)
+		if msg.Command == CmdPing {
+			if err := peer.Send(NewMessage(CmdPong, b.status().Args()...)); err != nil {
+				return err
+			}
+		}
+		b.sync()
+	case CmdOrder:
+		b.receiveOrder(peer, msg)
+	case CmdGetResumen:
+		return b.sendFile(peer, CmdResumenFile, HeadersFileName)
+	case CmdGetMNs:
+		return b.sendFile(peer, CmdMNFile, MNsFileName)
+	case CmdLastBlock:
+		if len(msg.Args) == 0 {
+			return fmt.Errorf("%w: %s without height", ErrInvalidMessage, CmdLastBlock)
+		}
+		after, err := strconv.ParseUint(msg.Args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s height '%s'", ErrInvalidMessage, CmdLastBlock, msg.Args[0])
+		}
+		data, err := b.chain.zipBlocks(after)
+		if err != nil || data == nil {
+			// Nothing we can send, Pascal nodes ask someone else
+			return nil
+		}
+		return peer.SendFile(NewMessage(CmdBlockZip), data)
+	case CmdResumenFile, CmdMNFile, CmdBlockZip:
+		// Files only answer our requests, others could overwrite the chain
+		if !b.isAnswer(peer, msg.Command) {
+			log.Debugf("legacy bridge: ignoring unrequested '%s' from %s", msg.Command, peer.name)
+			return peer.skipFile(cFileTimeout)
+		}
+		return b.receiveFile(peer, msg.Command)
+	default:
+		log.Debugf("legacy bridge: ignoring '%s' from %s", msg.Command, peer.name)
+	}
+	return nil
+}
+
+// Saves the file answering a sync request, then asks for more
+func (b *Bridge) receiveFile(peer *Peer, command string) error {
+	data, err := peer.readFile(cFileTimeout)
+	if err != nil {
+		return err
+	}
+
+	if command == CmdBlockZip {
+		count, err := b.chain.saveBlocks(data)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			// Asking again right away would get nothing again
+			return nil
+		}
+		last, _ := b.chain.last()
+		log.Infof("legacy bridge: got %d blocks from %s, up to %d", count, peer.name, last)
+	} else {
+		name := HeadersFileName
+		if command == CmdMNFile {
+			name = MNsFileName
+		}
+		if err := b.checkFile(name, data); err != nil {
+			return err
+		}
+		if err := b.chain.writeFile(name, data); err != nil {
+			log.Errorf("legacy bridge: could not save %s", err, name)
+			return nil
+		}
+		log.Infof("legacy bridge: got %s from %s", name, peer.name)
+	}
+
+	b.syncDone()
+	b.sync()
+	return nil
+}
+
+// Checks the headers against the blocks we saved, and the masternodes against
+// the hash the network agreed on when we asked for them
+func (b *Bridge) checkFile(name string, data []byte) error {
+	if name == HeadersFileName {
+		return b.chain.checkHeaders(data)
+	}
+
+	b.mu.Lock()
+	asked := b.mnsAsked
+	b.mu.Unlock()
+	if hash := md5Hex(data); !strings.HasPrefix(hash, strings.ToUpper(asked)) {
+		return fmt.Errorf("masternodes hash %s, the network has %s", hash, asked)
+	}
+	return nil
+}
+
+// Tells if a file from a peer answers the pending sync request
+func (b *Bridge) isAnswer(peer *Peer, command string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.asked == peer && b.askedFile == command && time.Now().Before(b.syncUntil)
+}
+
+// Sends a chain file, when we have it
+func (b *Bridge) sendFile(peer *Peer, command string, name string) error {
+	data, err := b.chain.readFile(name)
+	if err != nil || data == nil {
+		return nil
+	}
+	return peer.SendFile(NewMessage(command), data)
+}
+
+// Relays an order from a Pascal node to the others and the NosoGo network
+func (b *Bridge) receiveOrder(from *Peer, msg *Message) {
+	order, err := ParseOrder(msg.Args)
+	if err == nil {
+		err = order.Validate()
+	}
+	if err != nil {
+		log.Debugf("legacy bridge: invalid order from %s: %v", from.name, err)
+		return
+	}
+	if !b.rememberOrder(order.ID()) {
+		return
+	}
+
+	log.Debugf("legacy bridge: relaying order '%s' from %s", order.ID(), from.name)
+	relayed := NewMessage(CmdOrder, order.Args()...)
+	b.broadcast(relayed, from)
+	if b.onOrder != nil {
+		b.onOrder(relayed.String())
+	}
+}
+
+// Remembers an order, false when it was already seen
+func (b *Bridge) rememberOrder(id string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for seen, at := range b.orders {
+		if now.Sub(at) > cOrderMemory {
+			delete(b.orders, seen)
+		}
+	}
+	if _, seen := b.orders[id]; seen {
+		return false
+	}
+	b.orders[id] = now
+	return true
+}
+
+// Sends a message to every peer but one
+func (b *Bridge) broadcast(msg *Message, except *Peer) {
+	for _, peer := range b.Peers() {
+		if peer == except {
+			continue
+		}
+		if err := peer.Send(msg); err != nil {
+			log.Debugf("legacy bridge: could not send '%s' to %s: %v", msg.Command, peer.name, err)
+		}
+	}
+}
+
+func (b *Bridge) ping() {
+	b.broadcast(NewMessage(CmdPing, b.status().Args()...), nil)
+}
+
+// Asks a peer agreeing with the consensus for what we miss: blocks first,
+// then the headers and masternodes when their hashes differ. One request at
+// a time, until answered or timed out.
+func (b *Bridge) sync() {
+	network := b.Consensus()
+	if network == nil {
+		return
+	}
+	var source *Peer
+	for _, peer := range b.Peers() {
+		if status := peer.Status(); status != nil && status.LastBlock == network.LastBlock && status.LastHash == network.LastHash {
+			source = peer
+			break
+		}
+	}
+	if source == nil {
+		return
+	}
+	last, hasBlocks := b.chain.last()
+	_, headersHash, mnsHash := b.chain.hashes()
+
+	b.mu.Lock()
+	if time.Now().Before(b.syncUntil) {
+		b.mu.Unlock()
+		return
+	}
+	var (
+		request *Message
+		answer  string
+	)
+	switch {
+	case !hasBlocks || last < network.LastBlock:
+		request, answer = NewMessage(CmdLastBlock, strconv.FormatUint(last, 10)), CmdBlockZip
+	// Asked once per hash, in case ours is computed differently
+	case isSet(network.HeadersHash) && !strings.EqualFold(network.HeadersHash, headersHash) && b.headersAsked != network.HeadersHash:
+		b.headersAsked = network.HeadersHash
+		request, answer = NewMessage(CmdGetResumen), CmdResumenFile
+	case isSet(network.MNsHash) && !strings.HasPrefix(mnsHash, strings.ToUpper(network.MNsHash)) && b.mnsAsked != network.MNsHash:
+		b.mnsAsked = network.MNsHash
+		request, answer = NewMessage(CmdGetMNs), CmdMNFile
+	}
+	if request != nil {
+		b.syncUntil = time.Now().Add(cSyncTimeout)
+		b.asked, b.askedFile = source, answer
+	}
+	b.mu.Unlock()
+	if request == nil {
+		return
+	}
+
+	if err := source.Send(request); err != nil {
+		log.Debugf("legacy bridge: could not ask %s for '%s': %v", source.name, request.Command, err)
+		b.syncDone()
+	}
+}
+
+// Lets the next sync ask for more
+func (b *Bridge) syncDone() {
+	b.mu.Lock()
+	b.syncUntil = time.Time{}
+	b.asked, b.askedFile = nil, ""
+	b.mu.Unlock()
+}
+
+// The status we tell peers, that of the legacy chain we follow
+func (b *Bridge) status() *NodeStatus {
+	b.mu.Lock()
+	connections := len(b.peers)
+	b.mu.Unlock()
+
+	last, hasBlocks := b.chain.last()
+	lastHash, headersHash, mnsHash := b.chain.hashes()
+	status := &NodeStatus{
+		Connections: connections,
+		LastBlock:   last,
+		LastHash:    lastHash,
+		HeadersHash: headersHash,
+		ConStatus:   cConStatusConnected,
+		Port:        int(b.config.Port),
+		MNsHash:     mnsHash,
+	}
+	if len(status.MNsHash) > cMNsHashPrefix {
+		status.MNsHash = status.MNsHash[:cMNsHashPrefix]
+	}
+	if network := b.Consensus(); hasBlocks && network != nil && last >= network.LastBlock {
+		status.ConStatus = cConStatusSynced
+	}
+	return status
+}
+
+// Pascal nodes send null for what they don't have
+func isSet(value string) bool {
+	return value != "" && value != cNullField
+}
@@ -0,0 +1,315 @@
+package legacynet
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+// The chain files keep the names and layout of the NOSODATA folder of a Pascal node
+const (
+	BlocksFolderName = "BLOCKS"
+	BlockFileExt     = ".blk"
+	HeadersFileName  = "blchhead.nos"
+	MNsFileName      = "masternodes.txt"
+
+	// The headers file grows with the chain, about 80 bytes a block
+	cMaxFileSize = 128 * 1024 * 1024
+	// Blocks sent at once on $LASTBLOCK, as Pascal nodes do
+	cMaxZipBlocks = 100
+)
+
+// The Noso chain files as downloaded from Pascal nodes
+type chain struct {
+	folder string
+
+	mu        sync.Mutex
+	lastBlock uint64
+	hasBlocks bool
+	// Hashed once when written, peers get them on every ping
+	lastHash    string
+	headersHash string
+	mnsHash     string
+	// Hashes of the block files by height, filled when headers are checked
+	blockHashes []string
+}
+
+// Opens the chain folder, finding the last block of the chain there
+func openChain(folder string) (*chain, error) {
+	if err := utils.EnsureDir(filepath.Join(folder, BlocksFolderName), 0755); err != nil {
+		return nil, err
+	}
+
+	c := &chain{folder: folder}
+	entries, err := os.ReadDir(filepath.Join(folder, BlocksFolderName))
+	if err != nil {
+		return nil, err
+	}
+	heights := make(map[uint64]bool)
+	for _, entry := range entries {
+		if height, ok := BlockFileHeight(entry.Name()); ok {
+			heights[height] = true
+		}
+	}
+	// The chain goes as far as there are no holes from block zero
+	for height := uint64(0); heights[height]; height++ {
+		c.lastBlock, c.hasBlocks = height, true
+	}
+	if c.hasBlocks {
+		c.lastHash = fileHash(c.blockFile(c.lastBlock))
+	}
+	c.headersHash = fileHash(filepath.Join(folder, HeadersFileName))
+	c.mnsHash = fileHash(filepath.Join(folder, MNsFileName))
+	return c, nil
+}
+
+// BlockFileHeight returns the height of a block file name, like 1234.blk
+func BlockFileHeight(name string) (uint64, bool) {
+	number, found := strings.CutSuffix(name, BlockFileExt)
+	if !found {
+		return 0, false
+	}
+	height, err := strconv.ParseUint(number, 10, 64)
+	return height, err == nil
+}
+
+// Returns the last block, false when there are none yet
+func (c *chain) last() (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastBlock, c.hasBlocks
+}
+
+func (c *chain) blockFile(height uint64) string {
+	return filepath.Join(c.folder, BlocksFolderName, strconv.FormatUint(height, 10)+BlockFileExt)
+}
+
+// Returns the hashes of the last block, the headers and the masternodes.
+// Those are the MD5 of the files, as Pascal nodes compute them.
+func (c *chain) hashes() (lastHash string, headersHash string, mnsHash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastHash, c.headersHash, c.mnsHash
+}
+
+// Reads a chain file, nil when there is none
+func (c *chain) readFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(c.folder, name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (c *chain) writeFile(name string, data []byte) error {
+	if err := writeAtomic(filepath.Join(c.folder, name), data); err != nil {
+		return err
+	}
+
+	hash := md5Hex(data)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch name {
+	case HeadersFileName:
+		c.headersHash = hash
+	case MNsFileName:
+		c.mnsHash = hash
+	}
+	return nil
+}
+
+// Extracts the block files of a BLOCKZIP following the chain, in height order,
+// returning how many there were. It stops at the first height the archive misses,
+// a block not following the previous one is an error.
+func (c *chain) saveBlocks(data []byte) (int, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid block zip: %w", err)
+	}
+
+	files := make(map[uint64]*zip.File)
+	for _, file := range archive.File {
+		// Only the name counts, paths in the archive are those of the sender
+		if height, ok := BlockFileHeight(filepath.Base(filepath.FromSlash(file.Name))); ok {
+			files[height] = file
+		}
+	}
+
+	c.mu.Lock()
+	height, previousHash := c.lastBlock+1, c.lastHash
+	if !c.hasBlocks {
+		height = 0
+	}
+	c.mu.Unlock()
+
+	count := 0
+	for ; files[height] != nil; height++ {
+		block, err := unzipBlock(files[height], height)
+		if err != nil {
+			return count, err
+		}
+		decoded, err := legacy.DecodeBlock(block)
+		if err != nil {
+			return count, fmt.Errorf("block %d: %w", height, err)
+		}
+		if decoded.Number != int64(height) {
+			return count, fmt.Errorf("file of block %d holds block %d", height, decoded.Number)
+		}
+		if height > 0 && !strings.EqualFold(decoded.LastBlockHash, previousHash) {
+			return count, fmt.Errorf("block %d follows %s, not %s", height, decoded.LastBlockHash, previousHash)
+		}
+		if err := writeAtomic(c.blockFile(height), block); err != nil {
+			return count, err
+		}
+
+		previousHash = md5Hex(block)
+		c.mu.Lock()
+		c.lastBlock, c.hasBlocks, c.lastHash = height, true, previousHash
+		if uint64(len(c.blockHashes)) == height {
+			c.blockHashes = append(c.blockHashes, previousHash)
+		}
+		c.mu.Unlock()
+		count++
+	}
+	return count, nil
+}
+
+func unzipBlock(file *zip.File, height uint64) ([]byte, error) {
+	if file.UncompressedSize64 > cMaxFileSize {
+		return nil, fmt.Errorf("block %d of %d bytes over %d", height, file.UncompressedSize64, cMaxFileSize)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	block, err := io.ReadAll(io.LimitReader(reader, cMaxFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not extract block %d: %w", height, err)
+	}
+	return block, nil
+}
+
+// Checks a headers file holds one entry per block we have, with its hash
+func (c *chain) checkHeaders(data []byte) error {
+	entries, err := legacy.DecodeHeaders(data)
+	if err != nil {
+		return err
+	}
+	hashes, err := c.savedBlockHashes()
+	if err != nil {
+		return err
+	}
+
+	found := make([]bool, len(hashes))
+	for _, entry := range entries {
+		if entry.Block < 0 {
+			return fmt.Errorf("%w: block %d", legacy.ErrInvalidHeaders, entry.Block)
+		}
+		// Blocks we don't have yet can't be checked
+		if int(entry.Block) >= len(hashes) {
+			continue
+		}
+		if found[entry.Block] {
+			return fmt.Errorf("%w: block %d listed twice", legacy.ErrInvalidHeaders, entry.Block)
+		}
+		if !strings.EqualFold(entry.BlockHash, hashes[entry.Block]) {
+			return fmt.Errorf("%w: block %d hash %s, ours is %s", legacy.ErrInvalidHeaders, entry.Block, entry.BlockHash, hashes[entry.Block])
+		}
+		found[entry.Block] = true
+	}
+	if height := slices.Index(found, false); height >= 0 {
+		return fmt.Errorf("%w: block %d missing", legacy.ErrInvalidHeaders, height)
+	}
+	return nil
+}
+
+// Returns the hashes of the block files by height, hashing those not seen yet
+func (c *chain) savedBlockHashes() ([]string, error) {
+	last, ok := c.last()
+	if !ok {
+		return nil, nil
+	}
+	c.mu.Lock()
+	hashes := slices.Clone(c.blockHashes)
+	c.mu.Unlock()
+
+	for height := uint64(len(hashes)); height <= last; height++ {
+		block, err := os.ReadFile(c.blockFile(height))
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, md5Hex(block))
+	}
+
+	c.mu.Lock()
+	if len(hashes) > len(c.blockHashes) {
+		c.blockHashes = hashes
+	}
+	c.mu.Unlock()
+	return hashes, nil
+}
+
+// Zips the blocks after a height, as many as sent at once
+func (c *chain) zipBlocks(after uint64) ([]byte, error) {
+	last, ok := c.last()
+	if !ok || last <= after {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for height := after + 1; height <= last && height <= after+cMaxZipBlocks; height++ {
+		block, err := os.ReadFile(c.blockFile(height))
+		if err != nil {
+			return nil, err
+		}
+		writer, err := archive.Create(BlocksFolderName + "/" + strconv.FormatUint(height, 10) + BlockFileExt)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(block); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Upper case hex MD5 of a file, empty when it doesn't exist
+func fileHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return md5Hex(data)
+}
+
+// Upper case hex MD5 of data, as Pascal nodes write it
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Writes through a temporary file so readers never see half a file
+func writeAtomic(path string, data []byte) error {
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,175 @@
+package legacynet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// Orders are the longest lines, a few transfers of a few hundred bytes
+	cMaxLineSize  = 64 * 1024
+	cWriteTimeout = 10 * time.Second
+)
+
+var errLineTooLong = errors.New("legacy line too long")
+
+// Peer is a connection to a Pascal node
+type Peer struct {
+	// The configured address of outbound peers, the remote one of inbound peers
+	name    string
+	conn    net.Conn
+	reader  *bufio.Reader
+	inbound bool
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	status   *NodeStatus
+	lastSeen int64
+}
+
+func newPeer(conn net.Conn, name string, inbound bool) *Peer {
+	return &Peer{
+		name:     name,
+		conn:     conn,
+		reader:   bufio.NewReader(conn),
+		inbound:  inbound,
+		lastSeen: time.Now().Unix(),
+	}
+}
+
+// Name returns the address the peer is known by, as host:port
+func (p *Peer) Name() string {
+	return p.name
+}
+
+// Inbound tells if the peer connected to us
+func (p *Peer) Inbound() bool {
+	return p.inbound
+}
+
+// Status returns the last status the peer sent, nil before its first ping
+func (p *Peer) Status() *NodeStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status
+}
+
+// LastSeen returns the unix time of the last line from the peer
+func (p *Peer) LastSeen() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastSeen
+}
+
+func (p *Peer) setStatus(status *NodeStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status = status
+}
+
+// Send writes a message as a line
+func (p *Peer) Send(msg *Message) error {
+	return p.write([]byte(msg.String() + "\r\n"))
+}
+
+// SendFile writes a message followed by a file
+func (p *Peer) SendFile(msg *Message, data []byte) error {
+	if len(data) > cMaxFileSize {
+		return fmt.Errorf("file of %d bytes over %d", len(data), cMaxFileSize)
+	}
+	buf := make([]byte, 0, len(msg.String())+2+4+len(data))
+	buf = append(buf, msg.String()+"\r\n"...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	buf = append(buf, data...)
+	return p.write(buf)
+}
+
+func (p *Peer) write(data []byte) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	if err := p.conn.SetWriteDeadline(time.Now().Add(cWriteTimeout)); err != nil {
+		return err
+	}
+	_, err := p.conn.Write(data)
+	return err
+}
+
+// Reads the next line, waiting up to the timeout
+func (p *Peer) readLine(timeout time.Duration) (string, error) {
+	if err := p.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return "", err
+	}
+
+	var line []byte
+	for {
+		chunk, err := p.reader.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > cMaxLineSize {
+			return "", errLineTooLong
+		}
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return "", err
+		}
+	}
+
+	p.mu.Lock()
+	p.lastSeen = time.Now().Unix()
+	p.mu.Unlock()
+	// Lines end with CRLF, be lenient with a bare LF
+	for len(line) > 0 && (line[len(line)-1] == '\n' || line[len(line)-1] == '\r') {
+		line = line[:len(line)-1]
+	}
+	return string(line), nil
+}
+
+// Reads a file following a message, its size first
+func (p *Peer) readFile(timeout time.Duration) ([]byte, error) {
+	if err := p.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	var size uint32
+	if err := binary.Read(p.reader, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > cMaxFileSize {
+		return nil, fmt.Errorf("file of %d bytes over %d", size, cMaxFileSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(p.reader, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Reads past a file following a message, without keeping it
+func (p *Peer) skipFile(timeout time.Duration) error {
+	if err := p.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	var size uint32
+	if err := binary.Read(p.reader, binary.BigEndian, &size); err != nil {
+		return err
+	}
+	if size > cMaxFileSize {
+		return fmt.Errorf("file of %d bytes over %d", size, cMaxFileSize)
+	}
+	_, err := io.CopyN(io.Discard, p.reader, int64(size))
+	return err
+}
+
+// Close ends the connection
+func (p *Peer) Close() error {
+	return p.conn.Close()
+}
@@ -0,0 +1,336 @@
+package legacynet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+)
+
+// Lines of the Noso protocol are space separated and end with CRLF. Peers start
+// them with a header, `PSK <protocol> <version> <unix time>`, then the command
+// and its arguments. Files follow some replies as a 32 bit big endian size and
+// the raw bytes.
+const (
+	ProtocolVersion = 2
+	ProgramVersion  = "NosoGo"
+
+	cHeaderTag    = "PSK"
+	cHeaderFields = 4
+
+	CmdPing         = "$PING"
+	CmdPong         = "$PONG"
+	CmdGetResumen   = "$GETRESUMEN"
+	CmdLastBlock    = "$LASTBLOCK"
+	CmdGetMNs       = "$GETMNS"
+	CmdOrder        = "ORDER"
+	CmdNodeStatus   = "NODESTATUS"
+	CmdResumenFile  = "RESUMENFILE"
+	CmdBlockZip     = "BLOCKZIP"
+	CmdMNFile       = "MNFILE"
+	cOrderSeparator = "$"
+	cNullField      = "null"
+)
+
+var ErrInvalidMessage = errors.New("invalid legacy message")
+
+// Message is a line of the Noso protocol
+type Message struct {
+	// Protocol and version are zero and empty for lines without header
+	Protocol int
+	Version  string
+	Time     int64
+	Command  string
+	Args     []string
+}
+
+// NewMessage creates a message with our header
+func NewMessage(command string, args ...string) *Message {
+	return &Message{
+		Protocol: ProtocolVersion,
+		Version:  ProgramVersion,
+		Time:     time.Now().Unix(),
+		Command:  command,
+		Args:     args,
+	}
+}
+
+// ParseMessage parses a line, with or without header. The handshake line is a
+// header with no command.
+func ParseMessage(line string) (*Message, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty line", ErrInvalidMessage)
+	}
+	if fields[0] != cHeaderTag {
+		return &Message{Command: fields[0], Args: fields[1:]}, nil
+	}
+
+	if len(fields) < cHeaderFields {
+		return nil, fmt.Errorf("%w: short header '%s'", ErrInvalidMessage, line)
+	}
+	msg := &Message{Version: fields[2]}
+	// The handshake puts the address of the peer where the protocol goes
+	msg.Protocol, _ = strconv.Atoi(fields[1])
+	timestamp, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header time '%s'", ErrInvalidMessage, fields[3])
+	}
+	msg.Time = timestamp
+	if len(fields) > cHeaderFields {
+		msg.Command = fields[cHeaderFields]
+		msg.Args = fields[cHeaderFields+1:]
+	}
+	return msg, nil
+}
+
+// String formats the message as a line, without its end of line
+func (m *Message) String() string {
+	fields := make([]string, 0, cHeaderFields+1+len(m.Args))
+	if m.Protocol != 0 {
+		fields = append(fields, cHeaderTag, strconv.Itoa(m.Protocol), m.Version, strconv.FormatInt(m.Time, 10))
+	}
+	if m.Command != "" {
+		fields = append(fields, m.Command)
+	}
+	fields = append(fields, m.Args...)
+	return strings.Join(fields, " ")
+}
+
+// NodeStatus is what a node tells about itself on $PING and $PONG
+type NodeStatus struct {
+	Connections int
+	LastBlock   uint64
+	LastHash    string
+	SummaryHash string
+	Pending     int
+	HeadersHash string
+	ConStatus   int
+	Port        int
+	MNsHash     string
+	MNsCount    int
+	// Fields older nodes don't send are left empty
+	MNsChecks int
+	GVTsHash  string
+	CFGHash   string
+	PSOsHash  string
+}
+
+const (
+	// The fields every version of the protocol sends
+	cNodeStatusMinFields = 10
+	// Nodes only send the start of the masternodes hash
+	cMNsHashPrefix = 5
+)
+
+// ParseNodeStatus parses the arguments of a $PING or $PONG
+func ParseNodeStatus(args []string) (*NodeStatus, error) {
+	if len(args) < cNodeStatusMinFields {
+		return nil, fmt.Errorf("%w: node status of %d fields", ErrInvalidMessage, len(args))
+	}
+	field := func(index int) string {
+		if index < len(args) {
+			return args[index]
+		}
+		return ""
+	}
+	number := func(index int) int {
+		value, _ := strconv.Atoi(field(index))
+		return value
+	}
+
+	lastBlock, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: last block '%s'", ErrInvalidMessage, args[1])
+	}
+	return &NodeStatus{
+		Connections: number(0),
+		LastBlock:   lastBlock,
+		LastHash:    field(2),
+		SummaryHash: field(3),
+		Pending:     number(4),
+		HeadersHash: field(5),
+		ConStatus:   number(6),
+		Port:        number(7),
+		MNsHash:     field(8),
+		MNsCount:    number(9),
+		// Field 10 used to be the difficulty, it's always null now
+		MNsChecks: number(11),
+		GVTsHash:  field(12),
+		CFGHash:   field(13),
+		PSOsHash:  field(14),
+	}, nil
+}
+
+// Args formats the status as the arguments of a $PING or $PONG
+func (s *NodeStatus) Args() []string {
+	orNull := func(value string) string {
+		if value == "" {
+			return cNullField
+		}
+		return value
+	}
+	return []string{
+		strconv.Itoa(s.Connections),
+		strconv.FormatUint(s.LastBlock, 10),
+		orNull(s.LastHash),
+		orNull(s.SummaryHash),
+		strconv.Itoa(s.Pending),
+		orNull(s.HeadersHash),
+		strconv.Itoa(s.ConStatus),
+		strconv.Itoa(s.Port),
+		orNull(s.MNsHash),
+		strconv.Itoa(s.MNsCount),
+		cNullField,
+		strconv.Itoa(s.MNsChecks),
+		orNull(s.GVTsHash),
+		orNull(s.CFGHash),
+		orNull(s.PSOsHash),
+	}
+}
+
+// Transfer is a line of an order, as the Pascal wallet writes it
+type Transfer struct {
+	Type      string
+	OrderID   string
+	Lines     int
+	Timestamp int64
+	Reference string
+	Line      int
+	// Public key of the sender
+	Sender   string
+	Address  string
+	Receiver string
+	Fee      int64
+	Amount   int64
+	// Signature and id of the transfer
+	Signature string
+	ID        string
+}
+
+// The fields of a transfer, the type being written twice
+const cTransferFields = 14
+
+// Order is a set of transfers sent together
+type Order struct {
+	Transfers []Transfer
+}
+
+// ID returns the order id its transfers share
+func (o *Order) ID() string {
+	if len(o.Transfers) == 0 {
+		return ""
+	}
+	return o.Transfers[0].OrderID
+}
+
+// ParseOrder parses the arguments of an ORDER: the count of transfers, then
+// every transfer starting with '$'
+func ParseOrder(args []string) (*Order, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty order", ErrInvalidMessage)
+	}
+	count, err := strconv.Atoi(args[0])
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf("%w: order count '%s'", ErrInvalidMessage, args[0])
+	}
+
+	order := &Order{}
+	rest := args[1:]
+	for len(rest) > 0 {
+		if len(rest) < cTransferFields || !strings.HasPrefix(rest[0], cOrderSeparator) {
+			return nil, fmt.Errorf("%w: truncated transfer", ErrInvalidMessage)
+		}
+		transfer, err := parseTransfer(strings.TrimPrefix(rest[0], cOrderSeparator), rest[1:cTransferFields])
+		if err != nil {
+			return nil, err
+		}
+		order.Transfers = append(order.Transfers, *transfer)
+		rest = rest[cTransferFields:]
+	}
+
+	if len(order.Transfers) != count {
+		return nil, fmt.Errorf("%w: order of %d transfers has %d", ErrInvalidMessage, count, len(order.Transfers))
+	}
+	for _, transfer := range order.Transfers {
+		if transfer.OrderID != order.ID() {
+			return nil, fmt.Errorf("%w: transfers of different orders", ErrInvalidMessage)
+		}
+	}
+	return order, nil
+}
+
+func parseTransfer(orderType string, fields []string) (*Transfer, error) {
+	var err error
+	number := func(value string) int64 {
+		if err != nil {
+			return 0
+		}
+		var parsed int64
+		parsed, err = strconv.ParseInt(value, 10, 64)
+		return parsed
+	}
+
+	transfer := &Transfer{
+		Type:      orderType,
+		OrderID:   fields[0],
+		Lines:     int(number(fields[1])),
+		Timestamp: number(fields[3]),
+		Reference: fields[4],
+		Line:      int(number(fields[5])),
+		Sender:    fields[6],
+		Address:   fields[7],
+		Receiver:  fields[8],
+		Fee:       number(fields[9]),
+		Amount:    number(fields[10]),
+		Signature: fields[11],
+		ID:        fields[12],
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: transfer of order '%s': %v", ErrInvalidMessage, fields[0], err)
+	}
+	return transfer, nil
+}
+
+// Validate checks what can be checked without the legacy chain: the addresses
+// and amounts. Pascal nodes verify the signatures and balances.
+func (o *Order) Validate() error {
+	for _, t := range o.Transfers {
+		switch {
+		case t.Amount <= 0 || t.Fee < 0:
+			return fmt.Errorf("%w: transfer '%s' amounts", ErrInvalidMessage, t.ID)
+		case !legacy.IsValidHashAddress(t.Address):
+			return fmt.Errorf("%w: transfer '%s' sender address", ErrInvalidMessage, t.ID)
+		case !legacy.IsAddressOfPublicKey(t.Address, t.Sender):
+			return fmt.Errorf("%w: transfer '%s' public key", ErrInvalidMessage, t.ID)
+		}
+	}
+	return nil
+}
+
+// Args formats the order as the arguments of an ORDER
+func (o *Order) Args() []string {
+	args := []string{strconv.Itoa(len(o.Transfers))}
+	for _, t := range o.Transfers {
+		args = append(args,
+			cOrderSeparator+t.Type,
+			t.OrderID,
+			strconv.Itoa(t.Lines),
+			t.Type,
+			strconv.FormatInt(t.Timestamp, 10),
+			t.Reference,
+			strconv.Itoa(t.Line),
+			t.Sender,
+			t.Address,
+			t.Receiver,
+			strconv.FormatInt(t.Fee, 10),
+			strconv.FormatInt(t.Amount, 10),
+			t.Signature,
+			t.ID,
+		)
+	}
+	return args
+}
@@ -1,11 +1,14 @@
 package node
 
 import (
+	"errors"
+
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"google.golang.org/protobuf/proto"
 
 	log "github.com/Friends-Of-Noso/NosoGo/logger"
 	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
 )
 
 func (n *Node) handleBlocksTopic(sub *pubsub.Subscription) {
@@ -51,36 +54,53 @@ func (n *Node) handleBlocksTopic(sub *pubsub.Subscription) {
 
 func (n *Node) handleNewBlock(newBlock *pb.BlocksSubscriptionNewBlock) {
 	log.Infof("got new block(%d): %s, %s", newBlock.Block.Height, newBlock.Block.Hash, newBlock.Block.PreviousHash)
-	blockKey := n.sm.BlockKey(newBlock.Block.Height)
-	blockStorage := n.sm.BlockStorage()
-	if err := blockStorage.Put(blockKey, newBlock.Block); err != nil {
+	for index, transaction := range newBlock.Transactions {
+		log.Infof(
+			"  transaction %d, '%s', %d, '%s', %d, '%s', '%s', '%s', '%s', %d",
+			index,
+			transaction.Hash,
+			transaction.BlockHeight,
+			transaction.Type,
+			transaction.Timestamp,
+			transaction.PubKey,
+			transaction.Verify,
+			transaction.Sender,
+			transaction.Receiver,
+			transaction.Amount,
+		)
+	}
+
+	// A different block at a known height replaces it
+	replaced, err := n.Block(newBlock.Block.Height)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Errorf("could not get block %d from database", err, newBlock.Block.Height)
+		return
+	}
+
+	if err := n.sm.PutBlock(newBlock.Block, newBlock.Transactions); err != nil {
 		log.Errorf("could not store block %d on database", err, newBlock.Block.Height)
 		return
 	}
-	if len(newBlock.Transactions) > 0 {
-		transactionStorage := n.sm.TransactionStorage()
-		for index, transaction := range newBlock.Transactions {
-			log.Infof(
-				"  transaction %d, '%s', %d, '%s', %d, '%s', '%s', '%s', '%s', %d",
-				index,
-				transaction.Hash,
-				transaction.BlockHeight,
-				transaction.Type,
-				transaction.Timestamp,
-				transaction.PubKey,
-				transaction.Verify,
-				transaction.Sender,
-				transaction.Receiver,
-				transaction.Amount,
-			)
-			transactionKey := n.sm.TransactionKey(transaction.BlockHeight, transaction.Hash)
-			if err := transactionStorage.Put(transactionKey, transaction); err != nil {
-				log.Errorf("could not store transaction '%s' on database", err, transaction.Hash)
-				continue
-			}
 
-		}
+	if replaced != nil && replaced.Hash != newBlock.Block.Hash {
+		n.publishEvent(&pb.APIEvent{
+			Payload: &pb.APIEvent_Reorg{
+				Reorg: &pb.APIEventReorg{
+					Height:  newBlock.Block.Height,
+					OldHash: replaced.Hash,
+					NewHash: newBlock.Block.Hash,
+				},
+			},
+		})
 	}
+	n.publishEvent(&pb.APIEvent{
+		Payload: &pb.APIEvent_Block{
+			Block: &pb.APIEventBlock{
+				Block:        newBlock.Block,
+				Transactions: newBlock.Transactions,
+			},
+		},
+	})
 }
 
 func (n *Node) handleNewTransactions(newTransactions *pb.BlocksSubscriptionNewTransactions) {
@@ -99,16 +119,17 @@ func (n *Node) handleNewTransactions(newTransactions *pb.BlocksSubscriptionNewTr
 			transaction.Receiver,
 			transaction.Amount,
 		)
-		transactionKey := n.sm.TransactionKey(transaction.BlockHeight, transaction.Hash)
 		if transaction.BlockHeight == 0 {
-			transactionStorage := n.sm.PendingTransactionStorage()
-			if err := transactionStorage.Put(transactionKey, transaction); err != nil {
+			if err := n.sm.PutPendingTransaction(transaction); err != nil {
 				log.Error("could not store pending transaction", err)
+				continue
 			}
+			n.publishEvent(&pb.APIEvent{
+				Payload: &pb.APIEvent_Transaction{Transaction: transaction},
+			})
 		} else {
-			transactionStorage := n.sm.TransactionStorage()
-			if err := transactionStorage.Put(transactionKey, transaction); err != nil {
-				log.Error("could not store pending transaction", err)
+			if err := n.sm.PutTransaction(transaction); err != nil {
+				log.Error("could not store transaction", err)
 			}
 		}
 	}
@@ -0,0 +1,148 @@
+package node
+
+import (
+	"net"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"google.golang.org/protobuf/proto"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cHeartbeatInterval = 30 * time.Second
+)
+
+// Joins the connections topic and handles incoming heartbeats
+func (n *Node) joinConnectionsTopic() error {
+	connectionsTopic, err := n.pubSub.Join(CONNECTIONS_SUB)
+	if err != nil {
+		return err
+	}
+	n.topics[CONNECTIONS_SUB] = connectionsTopic
+
+	connectionsSub, err := connectionsTopic.Subscribe()
+	if err != nil {
+		return err
+	}
+	n.subscriptions[CONNECTIONS_SUB] = connectionsSub
+
+	n.wg.Add(1)
+	go n.handleConnectionsTopic(connectionsSub)
+
+	return nil
+}
+
+// Periodically announces this peer on the connections topic
+func (n *Node) publishHeartbeats() {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(cHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.propagateHeartbeat(); err != nil {
+			log.Error("could not propagate heartbeat", err)
+		}
+
+		select {
+		case <-n.ctx.Done():
+			log.Debug("node.publishHeartbeats exiting")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Propagates a heartbeat
+func (n *Node) propagateHeartbeat() error {
+	peer := proto.Clone(n.peer).(*pb.PeerInfo)
+	peer.LastSeen = time.Now().Unix()
+
+	msg := &pb.ConnectionsSubscriptionMessage{
+		Payload: &pb.ConnectionsSubscriptionMessage_Heartbeat{
+			Heartbeat: &pb.ConnectionsSubscriptionHeartbeat{
+				Peer:   peer,
+				Status: n.currentStatus(),
+			},
+		},
+	}
+
+	// Serialize the message
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	// Publish to the network
+	return n.topics[CONNECTIONS_SUB].Publish(n.ctx, data)
+}
+
+func (n *Node) handleConnectionsTopic(sub *pubsub.Subscription) {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.ctx.Done():
+			log.Debug("node.handleConnectionsTopic exiting")
+			return
+		default:
+			msg, err := sub.Next(n.ctx)
+			if err != nil {
+				continue
+			}
+
+			// Skip messages from ourselves
+			if msg.GetFrom() == n.p2pHost.ID() {
+				continue
+			}
+
+			// Unmarshal the message
+			networkMsg := &pb.ConnectionsSubscriptionMessage{}
+			if err := proto.Unmarshal(msg.Data, networkMsg); err != nil {
+				log.Error("error unmarshaling connections sub message", err)
+				continue
+			}
+
+			// Handle new payload
+			switch payload := networkMsg.Payload.(type) {
+			case *pb.ConnectionsSubscriptionMessage_Heartbeat:
+				n.handleHeartbeat(msg.GetFrom().String(), payload.Heartbeat)
+			default:
+				log.Warn("sent a message we don't recognize from the connections subscription")
+				continue
+			}
+		}
+	}
+}
+
+func (n *Node) handleHeartbeat(from string, heartbeat *pb.ConnectionsSubscriptionHeartbeat) {
+	peer := heartbeat.GetPeer()
+	if peer == nil {
+		return
+	}
+
+	// Messages are signed, so the origin can't lie about its ID
+	if peer.Id != from {
+		log.Warnf("heartbeat from '%s' claims to be from '%s'", from, peer.Id)
+		return
+	}
+
+	log.Debugf("got heartbeat from '%s' in mode '%s'", peer.Id, peer.Mode)
+
+	if !cfg.ValidModes[peer.Mode] {
+		log.Warnf("heartbeat from '%s' has an invalid mode '%s'", peer.Id, peer.Mode)
+		return
+	}
+
+	// Peers listening on all interfaces can't tell us their address
+	if ip := net.ParseIP(peer.Address); peer.Address == "" || (ip != nil && ip.IsUnspecified()) {
+		peer.Address = n.remoteAddress(peer.Id)
+	}
+	peer.LastSeen = time.Now().Unix()
+	peer.Connected, peer.Direction = n.connectionState(peer.Id)
+
+	n.addPeer(peer)
+}
@@ -0,0 +1,172 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+// RequestShutdown stops the node the same way an internal failure does
+func (n *Node) RequestShutdown() {
+	n.shutdown()
+}
+
+// Rescan checks the blockchain again and rebuilds the indexes
+func (n *Node) Rescan() error {
+	n.rescanMu.Lock()
+	defer n.rescanMu.Unlock()
+
+	if err := n.reScanBlockChain(); err != nil {
+		return err
+	}
+	return n.sm.Reindex()
+}
+
+// ConnectPeer dials a peer at a multiaddr ending in its ID
+func (n *Node) ConnectPeer(address string) (*pb.PeerInfo, error) {
+	addr, err := multiaddr.NewMultiaddr(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", api.ErrInvalidPeerAddress, err)
+	}
+	addrInfo, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", api.ErrInvalidPeerAddress, err)
+	}
+	if addrInfo.ID == n.p2pHost.ID() {
+		return nil, fmt.Errorf("%w: it is this node", api.ErrInvalidPeerAddress)
+	}
+	if n.isBanned(addrInfo.ID.String()) {
+		return nil, api.ErrPeerBanned
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, cLivenessDialTimeout)
+	defer cancel()
+	if err := n.p2pHost.Connect(ctx, *addrInfo); err != nil {
+		return nil, fmt.Errorf("%w: %v", api.ErrPeerUnreachable, err)
+	}
+	log.Infof("connected to peer '%s'", addrInfo.ID)
+
+	info := n.connectedPeerInfo(addrInfo.ID)
+	for _, peers := range n.peerLists() {
+		if known, ok := peers.Get(info.Id); ok {
+			info.Address, info.Port, info.Mode = known.Address, known.Port, known.Mode
+		}
+	}
+	info.Score = n.peerScore(info.Id)
+	return info, nil
+}
+
+// DisconnectPeer closes the connections to a peer, it stays known and may connect again
+func (n *Node) DisconnectPeer(id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", api.ErrInvalidPeerID, err)
+	}
+	if n.p2pHost.Network().Connectedness(peerID) != network.Connected {
+		return store.ErrNotFound
+	}
+
+	if err := n.p2pHost.Network().ClosePeer(peerID); err != nil {
+		return err
+	}
+	log.Infof("disconnected peer '%s'", id)
+	return nil
+}
+
+// BanPeer forgets a peer, drops its connections and refuses new ones
+func (n *Node) BanPeer(id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", api.ErrInvalidPeerID, err)
+	}
+
+	// Keep what we knew of it, to tell who it was
+	info := &pb.PeerInfo{Id: id}
+	for _, peers := range n.peerLists() {
+		if known, ok := peers.Get(id); ok {
+			info = known
+		}
+		peers.Remove(id)
+	}
+	if err := n.bannedPeerInfoStorage.Put(id, info); err != nil {
+		return err
+	}
+	log.Infof("banned peer '%s'", id)
+
+	if err := n.p2pHost.Network().ClosePeer(peerID); err != nil {
+		log.Errorf("could not disconnect banned peer '%s'", err, id)
+	}
+	return nil
+}
+
+// UnbanPeer lets a banned peer connect again
+func (n *Node) UnbanPeer(id string) error {
+	if _, err := peer.Decode(id); err != nil {
+		return fmt.Errorf("%w: %v", api.ErrInvalidPeerID, err)
+	}
+
+	banned, err := n.bannedPeerInfoStorage.Has(id)
+	if err != nil {
+		return err
+	}
+	if !banned {
+		return store.ErrNotFound
+	}
+	if err := n.bannedPeerInfoStorage.Delete(id); err != nil {
+		return err
+	}
+	log.Infof("lifted ban of peer '%s'", id)
+	return nil
+}
+
+// BannedPeers returns the banned peers
+func (n *Node) BannedPeers() ([]*pb.PeerInfo, error) {
+	return n.bannedPeerInfoStorage.ListValues(func() *pb.PeerInfo {
+		return &pb.PeerInfo{}
+	})
+}
+
+func (n *Node) isBanned(id string) bool {
+	banned, err := n.bannedPeerInfoStorage.Has(id)
+	if err != nil {
+		log.Errorf("could not check if peer '%s' is banned", err, id)
+		return false
+	}
+	return banned
+}
+
+// Describes a connected peer from what libp2p knows of it
+func (n *Node) connectedPeerInfo(peerID peer.ID) *pb.PeerInfo {
+	id := peerID.String()
+	connected, direction := n.connectionState(id)
+	info := &pb.PeerInfo{
+		Id:        id,
+		Address:   n.remoteAddress(id),
+		Connected: connected,
+		Direction: direction,
+	}
+	if latency := n.p2pHost.Peerstore().LatencyEWMA(peerID); latency > 0 {
+		info.LatencyMs = latency.Milliseconds()
+	}
+	return info
+}
+
+// How much the connection manager wants to keep a peer, pubsub tags raise it
+func (n *Node) peerScore(id string) int32 {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return 0
+	}
+	if tag := n.p2pHost.ConnManager().GetTagInfo(peerID); tag != nil {
+		return int32(tag.Value)
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+package node
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+// Starts the API server, in every mode
+func (n *Node) startAPI() error {
+	apiAddress, err := utils.ResolveToString(n.config.API.Address, int32(n.config.API.Port))
+	if err != nil {
+		return err
+	}
+
+	n.api, err = api.NewServer(n.ctx, n.shutdown, n.wg, n, apiAddress, n.config.API)
+	if err != nil {
+		return err
+	}
+	// Local tools read it to get admin access
+	if err := n.api.WriteCookie(n.config.GetCookieFile()); err != nil {
+		return err
+	}
+
+	n.wg.Add(1)
+	go n.api.Start()
+
+	if n.config.API.GRPCPort == 0 {
+		return nil
+	}
+	grpcAddress, err := utils.ResolveToString(n.config.API.Address, int32(n.config.API.GRPCPort))
+	if err != nil {
+		return err
+	}
+
+	n.grpc, err = api.NewGRPCServer(n.api, grpcAddress, n.config.API)
+	if err != nil {
+		return err
+	}
+
+	n.wg.Add(1)
+	go n.grpc.Start()
+
+	return nil
+}
+
+func (n *Node) shutdownAPI() {
+	if n.grpc != nil {
+		n.grpc.ShutDown()
+	}
+	if n.api != nil {
+		n.api.ShutDown()
+	}
+}
+
+// Streams an event to the API clients, once the API is up
+func (n *Node) publishEvent(event *pb.APIEvent) {
+	if n.api != nil {
+		n.api.Publish(event)
+	}
+}
+
+// BlocksStatus returns the height and hash of the last block
+func (n *Node) BlocksStatus() (*pb.APIBlocksStatus, error) {
+	// The stored status is the one safe to read from other goroutines
+	status := &pb.Status{}
+	if err := n.statusStorage.Get(pb.StatusKey, status); err != nil {
+		return nil, err
+	}
+
+	return &pb.APIBlocksStatus{
+		Height:   status.LastBlock,
+		LastHash: status.LastHash,
+	}, nil
+}
+
+// NetworkStatus returns who we are and how many peers we know of
+func (n *Node) NetworkStatus() *pb.APINetworkStatus {
+	return &pb.APINetworkStatus{
+		Network:        api.NetworkMainnet,
+		Mode:           n.peer.Mode,
+		PeerId:         n.peer.Id,
+		ConnectedPeers: uint32(len(n.p2pHost.Network().Peers())),
+		DnsPeers:       uint32(n.dnsPeers.Len()),
+		SeedPeers:      uint32(n.seedPeers.Len()),
+		NodePeers:      uint32(n.nodePeers.Len()),
+	}
+}
+
+// Block returns the block at the given height
+func (n *Node) Block(height uint64) (*pb.Block, error) {
+	block := &pb.Block{}
+	if err := n.blockStorage.Get(n.sm.BlockKey(height), block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// BlockByHash returns the block with the given hash
+func (n *Node) BlockByHash(hash string) (*pb.Block, error) {
+	return n.sm.GetBlockByHash(hash)
+}
+
+// Blocks returns the blocks between both heights, included
+func (n *Node) Blocks(from uint64, to uint64) ([]*pb.Block, error) {
+	return n.sm.GetBlocks(from, to)
+}
+
+// BlockTransactions returns the transactions of the block at the given height
+func (n *Node) BlockTransactions(height uint64) ([]*pb.Transaction, error) {
+	return n.sm.GetBlockTransactions(height)
+}
+
+// Transaction returns the confirmed transaction with the given hash
+func (n *Node) Transaction(hash string) (*pb.Transaction, error) {
+	return n.sm.GetTransactionByHash(hash)
+}
+
+// AddressBalance returns what an address holds from its confirmed transactions
+func (n *Node) AddressBalance(address string) (*pb.APIAddressBalance, error) {
+	totals, err := n.sm.GetAddressTotals(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.APIAddressBalance{
+		Address:      address,
+		Balance:      int64(totals.Received) - int64(totals.Sent),
+		Received:     totals.Received,
+		Sent:         totals.Sent,
+		Transactions: totals.Transactions,
+	}, nil
+}
+
+// AddressTransactions returns a page of an address' confirmed transactions, newest first
+func (n *Node) AddressTransactions(address string, cursor string, limit int) (*pb.APIAddressTransactions, error) {
+	transactions, next, err := n.sm.GetAddressTransactions(address, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.APIAddressTransactions{
+		Address:      address,
+		Transactions: transactions,
+		NextCursor:   next,
+	}, nil
+}
+
+// Peers returns the seeds and nodes we know of, and the other connected peers, sorted by ID
+func (n *Node) Peers() []*pb.PeerInfo {
+	peers := append(n.seedPeers.Response().Peers, n.nodePeers.Response().Peers...)
+	listed := make(map[string]bool, len(peers))
+	for _, info := range peers {
+		listed[info.Id] = true
+	}
+	// Connected peers that never told us their mode are peers too
+	for _, peerID := range n.p2pHost.Network().Peers() {
+		if id := peerID.String(); !listed[id] && !n.dnsPeers.Has(id) {
+			peers = append(peers, n.connectedPeerInfo(peerID))
+		}
+	}
+	for _, info := range peers {
+		info.Score = n.peerScore(info.Id)
+	}
+	peers = append(peers, n.legacyPeers()...)
+	slices.SortFunc(peers, func(a, b *pb.PeerInfo) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return peers
+}
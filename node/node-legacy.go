@@ -0,0 +1,134 @@
+package node
+
+import (
+	"net"
+	"strconv"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacynet"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+// Mode of the Pascal nodes in the peers list
+const cLegacyPeerMode = "legacy"
+
+// Joins the legacy orders topic and starts the bridge to Pascal nodes, when configured
+func (n *Node) startLegacy() error {
+	ordersTopic, err := n.pubSub.Join(LEGACY_ORDERS_SUB)
+	if err != nil {
+		return err
+	}
+	n.topics[LEGACY_ORDERS_SUB] = ordersTopic
+
+	ordersSub, err := ordersTopic.Subscribe()
+	if err != nil {
+		return err
+	}
+	n.subscriptions[LEGACY_ORDERS_SUB] = ordersSub
+
+	if n.config.Legacy.Enabled() {
+		address, err := utils.ResolveToString(n.config.Legacy.Address, n.config.Legacy.Port)
+		if err != nil {
+			return err
+		}
+		n.legacy, err = legacynet.NewBridge(
+			n.ctx,
+			n.shutdown,
+			n.wg,
+			address,
+			n.config.Legacy,
+			n.config.GetLegacyDataFolder(),
+			n.propagateLegacyOrder,
+		)
+		if err != nil {
+			return err
+		}
+
+		n.wg.Add(1)
+		go n.legacy.Start()
+	}
+
+	n.wg.Add(1)
+	go n.handleLegacyOrdersTopic(ordersSub)
+
+	return nil
+}
+
+func (n *Node) shutdownLegacy() {
+	if n.legacy != nil {
+		n.legacy.ShutDown()
+	}
+}
+
+// Propagates an order from a Pascal node to the other bridges
+func (n *Node) propagateLegacyOrder(line string) {
+	data, err := proto.Marshal(&pb.LegacyOrdersSubscriptionOrder{Line: line})
+	if err != nil {
+		log.Error("could not marshal legacy order", err)
+		return
+	}
+	if err := n.topics[LEGACY_ORDERS_SUB].Publish(n.ctx, data); err != nil {
+		log.Error("could not propagate legacy order", err)
+	}
+}
+
+func (n *Node) handleLegacyOrdersTopic(sub *pubsub.Subscription) {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.ctx.Done():
+			log.Debug("node.handleLegacyOrdersTopic exiting")
+			return
+		default:
+			msg, err := sub.Next(n.ctx)
+			if err != nil {
+				continue
+			}
+
+			// Skip messages from ourselves, and gossip them only when bridging
+			if msg.ReceivedFrom == n.p2pHost.ID() || n.legacy == nil {
+				continue
+			}
+
+			order := &pb.LegacyOrdersSubscriptionOrder{}
+			if err := proto.Unmarshal(msg.Data, order); err != nil {
+				log.Error("error unmarshaling legacy orders sub message", err)
+				continue
+			}
+			if err := n.legacy.RelayOrder(order.Line); err != nil {
+				log.Debugf("could not relay legacy order: %v", err)
+			}
+		}
+	}
+}
+
+// The Pascal nodes connected to the bridge, as peers
+func (n *Node) legacyPeers() []*pb.PeerInfo {
+	if n.legacy == nil {
+		return nil
+	}
+
+	var peers []*pb.PeerInfo
+	for _, peer := range n.legacy.Peers() {
+		info := &pb.PeerInfo{
+			Id:        peer.Name(),
+			Mode:      cLegacyPeerMode,
+			Connected: true,
+			Direction: pb.DirectionOutbound,
+			LastSeen:  peer.LastSeen(),
+		}
+		if peer.Inbound() {
+			info.Direction = pb.DirectionInbound
+		}
+		if host, port, err := net.SplitHostPort(peer.Name()); err == nil {
+			portNumber, _ := strconv.Atoi(port)
+			info.Address, info.Port = host, int32(portNumber)
+		}
+		peers = append(peers, info)
+	}
+	return peers
+}
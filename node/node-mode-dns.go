@@ -10,7 +10,8 @@ import (
 )
 
 const (
-	cDNSPortFlag = "dns-port"
+	cDNSPortFlag          = "dns-port"
+	cDNSResponderPortFlag = "dns-responder-port"
 )
 
 var (
@@ -40,7 +41,7 @@ func (n *Node) runModeDNS() {
 	err := checkPort(n.dnsPort, cDNSPortFlag, cfg.DefaultDNSPort)
 	if err != nil {
 		log.Error("error checking port", err)
-		close(*n.quit)
+		n.shutdown()
 		return
 	}
 
@@ -57,19 +58,44 @@ func (n *Node) runModeDNS() {
 	n.peer.Mode = "dns"
 	log.Debugf("peer Mode: %s", n.peer.Mode)
 
+	// Restore what we knew about the network
+	if err := n.loadPeerLists(); err != nil {
+		log.Error("could not load peer lists", err)
+	}
+	log.Debugf("loaded %d seeds and %d nodes", n.seedPeers.Len(), n.nodePeers.Len())
+
+	// Join the network so we can learn about seeds and nodes
+	n.connectToSeed()
+	if err := n.joinConnectionsTopic(); err != nil {
+		log.Error("failed to join connections topic", err)
+		n.shutdown()
+		return
+	}
+	n.wg.Add(1)
+	go n.checkPeersLiveness()
+
+	var dialBack dns.DialBackFunc
+	if n.config.DNS.DialBack {
+		dialBack = n.dialBack
+	}
+
 	dnsServer, err := dns.NewDNS(
 		n.ctx,
-		n.quit,
+		n.shutdown,
 		n.wg,
 		// n.cmd,
 		n.peer,
+		n.seedPeers,
+		n.nodePeers,
+		dialBack,
+		n.isBanned,
 		n.dnsAddress,
 		n.dnsPort,
-		dns.JSON,
+		n.config.DNS,
 	)
 	if err != nil {
 		log.Error("could not create DNS server", err)
-		close(*n.quit)
+		n.shutdown()
 		return
 	}
 
@@ -77,8 +103,55 @@ func (n *Node) runModeDNS() {
 	log.Debug("starting DNS server")
 	n.wg.Add(1)
 	go n.dns.Start()
+
+	if n.config.DNS.SeedDomain == "" {
+		return
+	}
+
+	err = checkPort(n.config.DNS.ResponderPort, cDNSResponderPortFlag, cfg.DefaultDNSResponderPort)
+	if err != nil {
+		log.Error("error checking port", err)
+		n.shutdown()
+		return
+	}
+
+	responderAddress, err := utils.ResolveToString(n.config.DNS.ResponderAddress, n.config.DNS.ResponderPort)
+	if err != nil {
+		log.Error("could not resolve DNS responder address", err)
+		n.shutdown()
+		return
+	}
+
+	dnsResponder, err := dns.NewResponder(
+		n.ctx,
+		n.shutdown,
+		n.wg,
+		n.seedPeers,
+		n.config.DNS.SeedDomain,
+		responderAddress,
+	)
+	if err != nil {
+		log.Error("could not create DNS responder", err)
+		n.shutdown()
+		return
+	}
+
+	n.dnsResponder = dnsResponder
+	log.Debug("starting DNS responder")
+	n.wg.Add(1)
+	go n.dnsResponder.Start()
 }
 
 func (n *Node) shutdownDNS() {
-	n.dns.ShutDown()
+	if n.dns != nil {
+		n.dns.ShutDown()
+	}
+	if n.dnsResponder != nil {
+		n.dnsResponder.ShutDown()
+	}
+
+	log.Info("saving peer lists...")
+	if err := n.savePeerLists(); err != nil {
+		log.Error("could not save peer lists", err)
+	}
 }
@@ -1,9 +1,6 @@
 package node
 
 import (
-	"github.com/libp2p/go-libp2p/core/peer"
-	"github.com/multiformats/go-multiaddr"
-
 	log "github.com/Friends-Of-Noso/NosoGo/logger"
 )
 
@@ -17,26 +14,9 @@ func (n *Node) runModeNode() {
 	}
 
 	// TODO: This needs to be changed to connect to a list of seeds in production
-	if n.seed != "" {
-		// connect to seed
-		log.Infof("connecting to seed: %s", n.seed)
-
-		// targetAddr, err := multiaddr.NewMultiaddr("/ip4/10.42.0.101/tcp/45050/p2p/12D3KooWKXcHejD288cQi32oqGR3aXEgY2sP3MAgpzwQ7V95CsNt")
-		if targetAddr, err := multiaddr.NewMultiaddr(n.seed); err != nil {
-			log.Error("invalid seed multiaddr", err)
-		} else {
-			if peerInfo, err := peer.AddrInfoFromP2pAddr(targetAddr); err != nil {
-				log.Error("failed to get peer info", err)
-			} else {
-				if err := n.p2pHost.Connect(n.ctx, *peerInfo); err != nil {
-					log.Error("failed to connect to seed", err)
-				} else {
-					log.Debugf("connected to seed '%s'", peerInfo.String())
-					// Should send a GetPeersRequest message now
-				}
-			}
-		}
-	}
+	n.connectToSeed()
+	n.bootstrapFromDNS()
+	n.registerWithDNS()
 
 	// Bootstrap DHT
 	// if err := n.dht.Bootstrap(n.ctx); err != nil {
@@ -49,7 +29,7 @@ func (n *Node) runModeNode() {
 	blockTopic, err := n.pubSub.Join(BLOCKS_SUB)
 	if err != nil {
 		log.Error("failed to join blocks topic", err)
-		close(*n.quit)
+		n.shutdown()
 		return
 	}
 	n.topics[BLOCKS_SUB] = blockTopic
@@ -58,7 +38,7 @@ func (n *Node) runModeNode() {
 	blockSub, err := blockTopic.Subscribe()
 	if err != nil {
 		log.Error("failed to subscribe to blocks topic", err)
-		close(*n.quit)
+		n.shutdown()
 		return
 	}
 
@@ -68,6 +48,22 @@ func (n *Node) runModeNode() {
 
 	n.subscriptions[BLOCKS_SUB] = blockSub
 
+	// Announce ourselves to the network
+	if err := n.joinConnectionsTopic(); err != nil {
+		log.Error("failed to join connections topic", err)
+		n.shutdown()
+		return
+	}
+	n.wg.Add(1)
+	go n.publishHeartbeats()
+
+	// Orders and the legacy chain of Pascal nodes
+	if err := n.startLegacy(); err != nil {
+		log.Error("failed to start legacy bridge", err)
+		n.shutdown()
+		return
+	}
+
 	// TODO: This must go away in production
 	// if err := n.loadStatus(); err != nil {
 	// 	log.Error("runModeNode.loadStatus", err)
@@ -102,5 +98,5 @@ func (n *Node) runModeNode() {
 }
 
 func (n *Node) shutdownNode() {
-	// NOTE: Does this have specific shutdown stuff?
+	n.shutdownLegacy()
 }
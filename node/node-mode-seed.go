@@ -6,6 +6,18 @@ import (
 
 func (n *Node) runModeSeed() {
 	log.Debug("Entering runModeSeed")
+
+	n.bootstrapFromDNS()
+	n.registerWithDNS()
+
+	// Announce ourselves to the network
+	if err := n.joinConnectionsTopic(); err != nil {
+		log.Error("failed to join connections topic", err)
+		n.shutdown()
+		return
+	}
+	n.wg.Add(1)
+	go n.publishHeartbeats()
 }
 
 func (n *Node) shutdownSeed() {
@@ -6,6 +6,18 @@ import (
 
 func (n *Node) runModeSuperNode() {
 	log.Debug("Entering runModeSuperNode")
+
+	n.bootstrapFromDNS()
+	n.registerWithDNS()
+
+	// Announce ourselves to the network
+	if err := n.joinConnectionsTopic(); err != nil {
+		log.Error("failed to join connections topic", err)
+		n.shutdown()
+		return
+	}
+	n.wg.Add(1)
+	go n.publishHeartbeats()
 }
 
 func (n *Node) shutdownSuperNode() {
@@ -1,7 +1,373 @@
 package node
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+	"github.com/multiformats/go-multiaddr"
+	"google.golang.org/protobuf/proto"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/dns"
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+const (
+	cLivenessInterval    = time.Minute
+	cLivenessDialTimeout = 10 * time.Second
+	cPeerStaleAfter      = 5 * time.Minute
+	cBootstrapTimeout    = 30 * time.Second
 )
 
 type Peers []peer.AddrInfo
+
+// Connects to the seed given on the command line
+func (n *Node) connectToSeed() {
+	if n.seed == "" {
+		return
+	}
+
+	log.Infof("connecting to seed: %s", n.seed)
+
+	targetAddr, err := multiaddr.NewMultiaddr(n.seed)
+	if err != nil {
+		log.Error("invalid seed multiaddr", err)
+		return
+	}
+
+	peerInfo, err := peer.AddrInfoFromP2pAddr(targetAddr)
+	if err != nil {
+		log.Error("failed to get peer info", err)
+		return
+	}
+
+	if err := n.p2pHost.Connect(n.ctx, *peerInfo); err != nil {
+		log.Error("failed to connect to seed", err)
+		return
+	}
+
+	log.Debugf("connected to seed '%s'", peerInfo.String())
+}
+
+// Asks the DNS servers for seeds and connects to them
+func (n *Node) bootstrapFromDNS() {
+	if n.config == nil || len(n.config.Node.DNSServers) == 0 {
+		return
+	}
+
+	client, err := dns.NewClient(n.config.Node.DNSServers, n.config.GetConfigFolder())
+	if err != nil {
+		log.Error("could not create dns client", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, cBootstrapTimeout)
+	defer cancel()
+
+	seeds, err := client.Seeds(ctx)
+	if err != nil {
+		log.Error("could not get seeds from dns", err)
+		return
+	}
+	log.Infof("got %d seeds from dns", len(seeds.Peers))
+
+	for _, info := range seeds.Peers {
+		peerID, err := peer.Decode(info.Id)
+		if err != nil || peerID == n.p2pHost.ID() {
+			continue
+		}
+		n.addPeer(info)
+		if err := n.dialPeer(ctx, peerID, info); err != nil {
+			log.Debugf("could not connect to seed '%s': %v", info.Id, err)
+			continue
+		}
+		log.Debugf("connected to seed '%s'", info.Id)
+	}
+}
+
+// Keeps the peer lists in sync with libp2p connection events
+func (n *Node) watchConnections() {
+	n.p2pHost.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(_ network.Network, conn network.Conn) {
+			id := conn.RemotePeer().String()
+			if n.isBanned(id) {
+				log.Debugf("dropping banned peer: '%s'", id)
+				// Closing from within the notification would deadlock
+				go conn.Close()
+				return
+			}
+			direction := directionString(conn.Stat().Direction)
+			log.Debugf("peer connected: '%s', %s", id, direction)
+			for _, peers := range n.peerLists() {
+				peers.Connect(id, direction, time.Now().Unix())
+			}
+		},
+		DisconnectedF: func(net network.Network, conn network.Conn) {
+			// There may be other connections still open to the same peer
+			if net.Connectedness(conn.RemotePeer()) == network.Connected {
+				return
+			}
+			id := conn.RemotePeer().String()
+			log.Debugf("peer disconnected: '%s'", id)
+			for _, peers := range n.peerLists() {
+				peers.Disconnect(id)
+			}
+		},
+	})
+}
+
+// Periodically checks if the known peers are still alive
+func (n *Node) checkPeersLiveness() {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(cLivenessInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			log.Debug("node.checkPeersLiveness exiting")
+			return
+		case <-ticker.C:
+			n.checkLiveness()
+			if err := n.savePeerLists(); err != nil {
+				log.Error("could not save peer lists", err)
+			}
+		}
+	}
+}
+
+// Dials all known peers we aren't connected to and drops the stale ones
+func (n *Node) checkLiveness() {
+	for _, peers := range []*pb.PeerList{n.seedPeers, n.nodePeers} {
+		for id, info := range peers.Peers() {
+			peerID, err := peer.Decode(id)
+			if err != nil {
+				log.Errorf("invalid peer ID '%s'", err, id)
+				peers.Remove(id)
+				continue
+			}
+
+			if n.p2pHost.Network().Connectedness(peerID) == network.Connected {
+				peers.Seen(id, time.Now().Unix())
+				n.recordLatency(peers, peerID)
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(n.ctx, cLivenessDialTimeout)
+			err = n.dialPeer(ctx, peerID, info)
+			cancel()
+			if err != nil {
+				log.Debugf("peer '%s' is unreachable: %v", id, err)
+				continue
+			}
+			peers.Seen(id, time.Now().Unix())
+			n.recordLatency(peers, peerID)
+		}
+
+		before := time.Now().Add(-cPeerStaleAfter).Unix()
+		for _, id := range peers.RemoveStale(before) {
+			log.Infof("dropped stale peer '%s'", id)
+		}
+	}
+}
+
+// Copies the latency libp2p measured to the peer into its list entry
+func (n *Node) recordLatency(peers *pb.PeerList, id peer.ID) {
+	if latency := n.p2pHost.Peerstore().LatencyEWMA(id); latency > 0 {
+		peers.SetLatency(id.String(), latency.Milliseconds())
+	}
+}
+
+// Dials a peer on its advertised address
+func (n *Node) dialPeer(ctx context.Context, id peer.ID, info *pb.PeerInfo) error {
+	addr, err := peerMultiaddr(info.Address, info.Port)
+	if err != nil {
+		return err
+	}
+
+	return n.p2pHost.Connect(ctx, peer.AddrInfo{
+		ID:    id,
+		Addrs: []multiaddr.Multiaddr{addr},
+	})
+}
+
+// Registers us on the DNS servers, so others find us through them
+func (n *Node) registerWithDNS() {
+	if n.config == nil || len(n.config.Node.DNSServers) == 0 {
+		return
+	}
+
+	client, err := dns.NewClient(n.config.Node.DNSServers, "")
+	if err != nil {
+		log.Error("could not create dns client", err)
+		return
+	}
+	req, err := dns.NewRegisterRequest(n.privateKey, proto.Clone(n.peer).(*pb.PeerInfo))
+	if err != nil {
+		log.Error("could not create dns registration", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, cBootstrapTimeout)
+	defer cancel()
+
+	if err := client.Register(ctx, req); err != nil {
+		log.Error("could not register with dns", err)
+		return
+	}
+	log.Infof("registered with dns as %s", n.peer.Mode)
+}
+
+// Confirms a peer registering on the DNS server is reachable on the address it
+// registered. The dial skips the swarm, which would reuse an open connection.
+func (n *Node) dialBack(ctx context.Context, info *pb.PeerInfo) error {
+	peerID, err := peer.Decode(info.Id)
+	if err != nil {
+		return err
+	}
+	addr, err := peerMultiaddr(info.Address, info.Port)
+	if err != nil {
+		return err
+	}
+
+	dialer, ok := n.p2pHost.Network().(*swarm.Swarm)
+	if !ok {
+		return n.p2pHost.Connect(network.WithForceDirectDial(ctx, "dns dial back"), peer.AddrInfo{
+			ID:    peerID,
+			Addrs: []multiaddr.Multiaddr{addr},
+		})
+	}
+	transport := dialer.TransportForDialing(addr)
+	if transport == nil {
+		return fmt.Errorf("no transport for '%s'", addr)
+	}
+	// The handshake checks the peer owns the ID
+	conn, err := transport.Dial(ctx, addr, peerID)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// Adds a peer to the list matching its mode
+func (n *Node) addPeer(info *pb.PeerInfo) {
+	if n.isBanned(info.Id) {
+		return
+	}
+	// A peer may have been restarted in another mode
+	for _, peers := range n.peerLists() {
+		peers.Remove(info.Id)
+	}
+	n.peerListForMode(info.Mode).Add(info)
+}
+
+func (n *Node) peerListForMode(mode string) *pb.PeerList {
+	switch mode {
+	case cfg.NodeModeDNS:
+		return n.dnsPeers
+	case cfg.NodeModeSeed:
+		return n.seedPeers
+	default:
+		return n.nodePeers
+	}
+}
+
+func (n *Node) peerLists() []*pb.PeerList {
+	return []*pb.PeerList{n.dnsPeers, n.seedPeers, n.nodePeers}
+}
+
+// Returns the remote address we see for the peer
+func (n *Node) remoteAddress(id string) string {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return ""
+	}
+
+	for _, conn := range n.p2pHost.Network().ConnsToPeer(peerID) {
+		if address := addressFromMultiaddr(conn.RemoteMultiaddr()); address != "" {
+			return address
+		}
+	}
+
+	for _, addr := range n.p2pHost.Peerstore().Addrs(peerID) {
+		if address := addressFromMultiaddr(addr); address != "" {
+			return address
+		}
+	}
+
+	return ""
+}
+
+// Returns if we are connected to the peer and in what direction
+func (n *Node) connectionState(id string) (bool, string) {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return false, ""
+	}
+
+	for _, conn := range n.p2pHost.Network().ConnsToPeer(peerID) {
+		return true, directionString(conn.Stat().Direction)
+	}
+
+	return false, ""
+}
+
+// Loads the persisted seeds and nodes
+func (n *Node) loadPeerLists() error {
+	if err := loadPeerList(n.sm.SeedPeerInfoStorage(), n.seedPeers); err != nil {
+		return err
+	}
+	return loadPeerList(n.sm.NodePeerInfoStorage(), n.nodePeers)
+}
+
+// Persists the seeds and nodes
+func (n *Node) savePeerLists() error {
+	if err := savePeerList(n.sm, n.sm.SeedPeerInfoStorage(), n.seedPeers); err != nil {
+		return err
+	}
+	return savePeerList(n.sm, n.sm.NodePeerInfoStorage(), n.nodePeers)
+}
+
+func loadPeerList(storage *store.Storage[*pb.PeerInfo], peers *pb.PeerList) error {
+	values, err := storage.ListValues(func() *pb.PeerInfo {
+		return &pb.PeerInfo{}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, info := range values {
+		// We only know they're connected when we see it
+		info.Connected = false
+		info.Direction = ""
+		peers.Add(info)
+	}
+
+	return nil
+}
+
+func savePeerList(sm *store.StorageManager, storage *store.Storage[*pb.PeerInfo], peers *pb.PeerList) error {
+	keys, err := storage.ListKeys()
+	if err != nil {
+		return err
+	}
+
+	batch := storage.NewBatch()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	for id, info := range peers.Peers() {
+		if err := batch.Put(id, info); err != nil {
+			return err
+		}
+	}
+
+	return batch.Write(sm.GetDB())
+}
@@ -7,6 +7,8 @@ import (
 const (
 	BLOCKS_SUB      = "blocks"
 	CONNECTIONS_SUB = "connections"
+	// Every node joins it so orders reach the bridges to Pascal nodes
+	LEGACY_ORDERS_SUB = "legacy-orders"
 )
 
 type (
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"time"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// SubmitTransaction validates a signed transaction, adds it to the pending ones
+// and gossips it to the network. Returns the transaction hash.
+func (n *Node) SubmitTransaction(transaction *pb.Transaction) (string, error) {
+	if err := transaction.ValidatePending(time.Now().Unix()); err != nil {
+		return "", err
+	}
+
+	if err := n.addPendingTransaction(transaction); err != nil {
+		return "", err
+	}
+	log.Infof("accepted transaction '%s'", transaction.Hash)
+	n.publishEvent(&pb.APIEvent{
+		Payload: &pb.APIEvent_Transaction{Transaction: transaction},
+	})
+
+	// It's pending here already, other nodes can still get it from a block
+	newTransactions := &pb.BlocksSubscriptionNewTransactions{
+		Transactions: []*pb.Transaction{transaction},
+	}
+	if err := n.propagateNewTransactions(newTransactions); err != nil {
+		log.Error("could not propagate transaction", err)
+	}
+
+	return transaction.Hash, nil
+}
+
+// Stores a transaction as pending unless it's known or the sender can't afford it.
+// Submissions are serialized so two of them can't both spend the same balance.
+func (n *Node) addPendingTransaction(transaction *pb.Transaction) error {
+	n.submitMu.Lock()
+	defer n.submitMu.Unlock()
+
+	key := n.sm.TransactionKey(transaction.BlockHeight, transaction.Hash)
+	known, err := n.sm.PendingTransactionStorage().Has(key)
+	if err != nil {
+		return err
+	}
+	if _, err := n.sm.GetTransactionByHash(transaction.Hash); known || err == nil {
+		return pb.ErrTransactionKnown
+	}
+
+	if err := n.checkBalance(transaction); err != nil {
+		return err
+	}
+
+	return n.sm.PutPendingTransaction(transaction)
+}
+
+// Checks the sender can afford the transaction on top of its pending ones
+func (n *Node) checkBalance(transaction *pb.Transaction) error {
+	totals, err := n.sm.GetAddressTotals(transaction.Sender)
+	if err != nil {
+		return err
+	}
+
+	pending, err := n.sm.GetPendingTransactionsBySender(transaction.Sender)
+	if err != nil {
+		return err
+	}
+	spent := totals.Sent + transaction.Amount + transaction.Fee
+	for _, other := range pending {
+		spent += other.Amount + other.Fee
+	}
+
+	if spent > totals.Received {
+		return &pb.TransactionError{Field: "amount", Reason: "exceeds the sender's balance"}
+	}
+	return nil
+}
@@ -2,8 +2,14 @@ package node
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"runtime"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/multiformats/go-multiaddr"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
 )
 
 // Checks for privileges of the user running the application.
@@ -19,3 +25,38 @@ func checkPort(port int32, flag string, defaultPort int) error {
 
 	return nil
 }
+
+// Extracts the IP address from a multiaddr
+func addressFromMultiaddr(addr multiaddr.Multiaddr) string {
+	if address, err := addr.ValueForProtocol(multiaddr.P_IP4); err == nil {
+		return address
+	}
+	if address, err := addr.ValueForProtocol(multiaddr.P_IP6); err == nil {
+		return address
+	}
+	return ""
+}
+
+// Builds the TCP multiaddr for an address and port
+func peerMultiaddr(address string, port int32) (multiaddr.Multiaddr, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid address '%s'", address)
+	}
+	if ip.To4() != nil {
+		return multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip.String(), port))
+	}
+	return multiaddr.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", ip.String(), port))
+}
+
+// Converts a libp2p direction to the one used on PeerInfo
+func directionString(direction network.Direction) string {
+	switch direction {
+	case network.DirInbound:
+		return pb.DirectionInbound
+	case network.DirOutbound:
+		return pb.DirectionOutbound
+	default:
+		return ""
+	}
+}
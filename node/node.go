@@ -15,8 +15,10 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/Friends-Of-Noso/NosoGo/api"
 	cfg "github.com/Friends-Of-Noso/NosoGo/config"
 	"github.com/Friends-Of-Noso/NosoGo/dns"
+	"github.com/Friends-Of-Noso/NosoGo/legacynet"
 	log "github.com/Friends-Of-Noso/NosoGo/logger"
 	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
 	"github.com/Friends-Of-Noso/NosoGo/store"
@@ -27,10 +29,14 @@ const (
 	cNodePortFlag = "node-port"
 )
 
+var errNotOnBlocksTopic = errors.New("not subscribed to the blocks topic")
+
 type Node struct {
 	// cmd                   *cobra.Command
 	ctx                   context.Context
 	quit                  *chan struct{}
+	quitOnce              sync.Once
+	config                *cfg.Config
 	wg                    *sync.WaitGroup
 	peer                  *pb.PeerInfo
 	p2pHost               host.Host
@@ -44,6 +50,13 @@ type Node struct {
 	seedPeers             *pb.PeerList
 	nodePeers             *pb.PeerList
 	dns                   *dns.DNS
+	dnsResponder          *dns.Responder
+	api                   *api.Server
+	grpc                  *api.GRPCServer
+	legacy                *legacynet.Bridge
+	rescanMu              sync.Mutex
+	statusMu              sync.RWMutex
+	submitMu              sync.Mutex
 	dnsAddress            string
 	dnsPort               int32
 	statusStorage         *store.Storage[*pb.Status]
@@ -186,6 +199,7 @@ func NewNode(
 		// cmd:                   cmd,
 		ctx:                   ctx,
 		quit:                  quit,
+		config:                config,
 		wg:                    wg,
 		peer:                  peer,
 		dnsAddress:            dnsAddress,
@@ -197,9 +211,9 @@ func NewNode(
 		privateKey:            privateKey,
 		publicKey:             publicKey,
 		sm:                    sm,
-		dnsPeers:              &pb.PeerList{},
-		seedPeers:             &pb.PeerList{},
-		nodePeers:             &pb.PeerList{},
+		dnsPeers:              pb.NewPeerList(),
+		seedPeers:             pb.NewPeerList(),
+		nodePeers:             pb.NewPeerList(),
 		status:                &pb.Status{},
 		statusStorage:         sm.StatusStorage(),
 		blockStorage:          sm.BlockStorage(),
@@ -216,7 +230,15 @@ func (n *Node) Start() {
 
 	if err := n.startUp(); err != nil {
 		log.Errorf("failed calling startUp", err)
-		close(*n.quit)
+		n.shutdown()
+		return
+	}
+
+	n.watchConnections()
+
+	if err := n.startAPI(); err != nil {
+		log.Error("failed to start api server", err)
+		n.shutdown()
 		return
 	}
 
@@ -233,8 +255,17 @@ func (n *Node) Start() {
 
 }
 
+// Asks for the node to stop, closing the quit channel once. The servers the
+// node starts get it to stop the node when they fail.
+func (n *Node) shutdown() {
+	n.quitOnce.Do(func() {
+		close(*n.quit)
+	})
+}
+
 func (n *Node) Shutdown() {
 	log.Info("node shutting down...")
+	defer n.shutdown()
 
 	// See if there's custom  cleanup for each mode
 	switch n.peer.Mode {
@@ -248,6 +279,8 @@ func (n *Node) Shutdown() {
 		n.shutdownNode()
 	}
 
+	n.shutdownAPI()
+
 	// Close the database
 	log.Info("closing database...")
 	n.sm.Close()
@@ -257,20 +290,36 @@ func (n *Node) Shutdown() {
 
 // Loads the status
 func (n *Node) loadStatus() error {
+	n.statusMu.Lock()
+	defer n.statusMu.Unlock()
+
 	if err := n.statusStorage.Get(pb.StatusKey, n.status); err != nil {
 		return err
 	}
 	return nil
 }
 
-// Loads the status
-func (n *Node) saveStatus() error {
+// Sets and saves the status, the API may rescan from its own goroutine
+func (n *Node) setStatus(lastBlock uint64, lastHash string) error {
+	n.statusMu.Lock()
+	defer n.statusMu.Unlock()
+
+	n.status.LastBlock = lastBlock
+	n.status.LastHash = lastHash
 	if err := n.statusStorage.Put(pb.StatusKey, n.status); err != nil {
 		return err
 	}
 	return nil
 }
 
+// Returns a copy of the status
+func (n *Node) currentStatus() *pb.Status {
+	n.statusMu.RLock()
+	defer n.statusMu.RUnlock()
+
+	return proto.Clone(n.status).(*pb.Status)
+}
+
 // Propagates a new block
 func (n *Node) propagateNewBlock(newblock *pb.BlocksSubscriptionNewBlock) error {
 	// Create network message
@@ -286,11 +335,17 @@ func (n *Node) propagateNewBlock(newblock *pb.BlocksSubscriptionNewBlock) error
 		return fmt.Errorf("failed to marshal block: %w", err)
 	}
 
+	// Only modes following the chain are on the blocks topic
+	topic, ok := n.topics[BLOCKS_SUB]
+	if !ok {
+		return errNotOnBlocksTopic
+	}
+
 	// Publish to the network
-	return n.topics[BLOCKS_SUB].Publish(n.ctx, data)
+	return topic.Publish(n.ctx, data)
 }
 
-// Propagates a new block
+// Propagates new transactions
 func (n *Node) propagateNewTransactions(newTransactions *pb.BlocksSubscriptionNewTransactions) error {
 	// Create network message
 	msg := &pb.BlocksSubscriptionMessage{
@@ -305,8 +360,14 @@ func (n *Node) propagateNewTransactions(newTransactions *pb.BlocksSubscriptionNe
 		return err
 	}
 
+	// Only modes following the chain are on the blocks topic
+	topic, ok := n.topics[BLOCKS_SUB]
+	if !ok {
+		return errNotOnBlocksTopic
+	}
+
 	// Publish to the network
-	return n.topics[BLOCKS_SUB].Publish(n.ctx, data)
+	return topic.Publish(n.ctx, data)
 }
 
 func (n *Node) startUp() error {
@@ -354,6 +415,19 @@ func (n *Node) startUp() error {
 			}
 		}
 	}
+
+	// Databases from before the current indexes need them built
+	inSync, err := n.sm.IndexesInSync()
+	if err != nil {
+		return err
+	}
+	if !inSync {
+		log.Info("indexes out of sync: re-indexing")
+		if err := n.sm.Reindex(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -362,19 +436,23 @@ func (n *Node) initiateBlockChain() error {
 	log.Info("no blockchain found, creating it")
 	blockZero := pb.NewBlockZero()
 
-	blockZeroKey := n.sm.BlockKey(blockZero.Height)
-	if err := n.blockStorage.Put(blockZeroKey, blockZero); err != nil {
+	// Indexed from the start, so there's no re-indexing to do
+	batch := n.sm.NewBlockBatch()
+	if err := batch.PutBlock(blockZero, nil); err != nil {
 		return err
 	}
 	status := &pb.Status{
 		LastBlock: blockZero.Height,
 		LastHash:  blockZero.Hash,
 	}
-	if err := n.statusStorage.Put(pb.StatusKey, status); err != nil {
+	if err := batch.PutStatus(pb.StatusKey, status); err != nil {
+		return err
+	}
+	if err := batch.PutIndexesVersion(); err != nil {
 		return err
 	}
 
-	return nil
+	return batch.Write()
 }
 
 // Re-scans the database and tries to recover status
@@ -387,42 +465,15 @@ func (n *Node) reScanBlockChain() error {
 		return err
 	}
 
-	// Check that all blocks are sequential
-	var (
-		height   uint64 = 0
-		previous        = pb.NewBlockZero()
-	)
-
-	for _, block := range blocks {
-		if block.Height != height {
-			return fmt.Errorf("mismatched block height, expected %d, got %d", height, block.Height)
-		}
-
-		if height == 0 && block.PreviousHash != previous.PreviousHash {
-			return fmt.Errorf("chain is broken: block %d does not have the the correct previous hash", block.Height)
-		}
-		if height != 0 && block.PreviousHash != previous.Hash {
-			return fmt.Errorf("chain is broken: block %d does not have the the correct previous hash", block.Height)
-		}
-
-		// ????????????????????????
-		// if err := n.loadStatus(); err != nil {
-		// 	log.Error("reScanBlockChain.loadStatus", err)
-		// 	close(*n.quit)
-		// 	return err
-		// }
-
-		n.status.LastBlock = block.Height
-		n.status.LastHash = block.Hash
-
-		if err := n.saveStatus(); err != nil {
-			log.Error("reScanBlockChain.saveStatus", err)
-			close(*n.quit)
+	// The status follows the chain up to its last good block, even when broken
+	last, chainErr := checkChain(blocks)
+	if last != nil {
+		if err := n.setStatus(last.Height, last.Hash); err != nil {
 			return err
 		}
-
-		previous = block
-		height++
+	}
+	if chainErr != nil {
+		return chainErr
 	}
 
 	// Check for orphaned transactions
@@ -444,3 +495,31 @@ func (n *Node) reScanBlockChain() error {
 	}
 	return nil
 }
+
+// Checks that all blocks are sequential, returns the last one that is
+func checkChain(blocks []*pb.Block) (*pb.Block, error) {
+	var (
+		height   uint64 = 0
+		previous        = pb.NewBlockZero()
+		last     *pb.Block
+	)
+
+	for _, block := range blocks {
+		if block.Height != height {
+			return last, fmt.Errorf("mismatched block height, expected %d, got %d", height, block.Height)
+		}
+
+		if height == 0 && block.PreviousHash != previous.PreviousHash {
+			return last, fmt.Errorf("chain is broken: block %d does not have the the correct previous hash", block.Height)
+		}
+		if height != 0 && block.PreviousHash != previous.Hash {
+			return last, fmt.Errorf("chain is broken: block %d does not have the the correct previous hash", block.Height)
+		}
+
+		last = block
+		previous = block
+		height++
+	}
+
+	return last, nil
+}
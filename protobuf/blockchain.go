@@ -3,4 +3,8 @@ package protobuf
 const (
 	salt      = "Noso"
 	StatusKey = "status-main"
+	// Last block imported from legacy block files
+	LegacyImportKey = "legacy-import"
+	// Version of the indexes
+	IndexesKey = "indexes"
 )
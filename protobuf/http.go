@@ -0,0 +1,79 @@
+package protobuf
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// Content types used when serving messages over HTTP
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtoBuf = "application/x-protobuf"
+	ContentTypeText     = "text/plain"
+)
+
+// MarshalJSON encodes a message as JSON, keeping the field names of the
+// .proto file and fields left at their zero value
+func MarshalJSON(msg proto.Message) ([]byte, error) {
+	return protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+}
+
+// UnmarshalJSON decodes JSON into a message, ignoring fields it doesn't know
+func UnmarshalJSON(data []byte, msg proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+// WriteJSON writes a message as a JSON response
+func WriteJSON(w http.ResponseWriter, msg proto.Message) {
+	data, err := MarshalJSON(msg)
+	if err != nil {
+		http.Error(w, "failed to encode to JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.Write(append(data, '\n'))
+}
+
+// WriteProtoBuf writes a message as a ProtoBuf response
+func WriteProtoBuf(w http.ResponseWriter, msg proto.Message) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		http.Error(w, "failed to marshal protobuf", http.StatusInternalServerError)
+		return
+	}
+
+	protoMime := fmt.Sprintf("%s; proto=%s", ContentTypeProtoBuf, msg.ProtoReflect().Descriptor().FullName())
+	w.Header().Set("Content-Type", protoMime)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
+
+// WriteText writes a message as a ProtoBuf text format response
+func WriteText(w http.ResponseWriter, msg proto.Message) {
+	data, err := prototext.MarshalOptions{Multiline: true}.Marshal(msg)
+	if err != nil {
+		http.Error(w, "failed to marshal text", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeText+"; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
+
+// Write writes a message in the given content type
+func Write(w http.ResponseWriter, contentType string, msg proto.Message) {
+	switch contentType {
+	case ContentTypeProtoBuf:
+		WriteProtoBuf(w, msg)
+	case ContentTypeText:
+		WriteText(w, msg)
+	default:
+		WriteJSON(w, msg)
+	}
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.6
 // 	protoc        v3.21.12
 // source: protobuf/messages.proto
 
@@ -9,6 +9,7 @@ package protobuf
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -151,16 +152,18 @@ func (x *Block) GetMerkleRoot() string {
 }
 
 type Transaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	BlockHeight   uint64                 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Amount        uint64                 `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
-	PubKey        string                 `protobuf:"bytes,6,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
-	Verify        string                 `protobuf:"bytes,7,opt,name=verify,proto3" json:"verify,omitempty"`
-	Sender        string                 `protobuf:"bytes,8,opt,name=sender,proto3" json:"sender,omitempty"`
-	Receiver      string                 `protobuf:"bytes,9,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Hash        string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockHeight uint64                 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Type        string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp   int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Amount      uint64                 `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	PubKey      string                 `protobuf:"bytes,6,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Verify      string                 `protobuf:"bytes,7,opt,name=verify,proto3" json:"verify,omitempty"`
+	Sender      string                 `protobuf:"bytes,8,opt,name=sender,proto3" json:"sender,omitempty"`
+	Receiver    string                 `protobuf:"bytes,9,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	// Paid by the sender on top of the amount
+	Fee           uint64 `protobuf:"varint,10,opt,name=fee,proto3" json:"fee,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -258,22 +261,123 @@ func (x *Transaction) GetReceiver() string {
 	return ""
 }
 
+func (x *Transaction) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+// Secondary index entry, pointing at the key of the indexed value
+type StorageIndex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageIndex) Reset() {
+	*x = StorageIndex{}
+	mi := &file_protobuf_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageIndex) ProtoMessage() {}
+
+func (x *StorageIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageIndex.ProtoReflect.Descriptor instead.
+func (*StorageIndex) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StorageIndex) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// Version of the indexes a database was built with
+type IndexesStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexesStatus) Reset() {
+	*x = IndexesStatus{}
+	mi := &file_protobuf_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexesStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexesStatus) ProtoMessage() {}
+
+func (x *IndexesStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexesStatus.ProtoReflect.Descriptor instead.
+func (*IndexesStatus) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IndexesStatus) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // Peers
 type PeerInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
-	Mode          string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
-	Connected     bool                   `protobuf:"varint,5,opt,name=connected,proto3" json:"connected,omitempty"`
-	Direction     string                 `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Address   string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Port      int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Id        string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Mode      string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	Connected bool                   `protobuf:"varint,5,opt,name=connected,proto3" json:"connected,omitempty"`
+	Direction string                 `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	LastSeen  int64                  `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	LatencyMs int64                  `protobuf:"varint,8,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// Connection manager tag value, how much the node wants to keep the peer
+	Score         int32 `protobuf:"varint,9,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PeerInfo) Reset() {
 	*x = PeerInfo{}
-	mi := &file_protobuf_messages_proto_msgTypes[3]
+	mi := &file_protobuf_messages_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -285,7 +389,7 @@ func (x *PeerInfo) String() string {
 func (*PeerInfo) ProtoMessage() {}
 
 func (x *PeerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[3]
+	mi := &file_protobuf_messages_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +402,7 @@ func (x *PeerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerInfo.ProtoReflect.Descriptor instead.
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{3}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PeerInfo) GetAddress() string {
@@ -343,6 +447,27 @@ func (x *PeerInfo) GetDirection() string {
 	return ""
 }
 
+func (x *PeerInfo) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 // Blocks Subscription
 type BlocksSubscriptionNewBlock struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -354,7 +479,7 @@ type BlocksSubscriptionNewBlock struct {
 
 func (x *BlocksSubscriptionNewBlock) Reset() {
 	*x = BlocksSubscriptionNewBlock{}
-	mi := &file_protobuf_messages_proto_msgTypes[4]
+	mi := &file_protobuf_messages_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -366,7 +491,7 @@ func (x *BlocksSubscriptionNewBlock) String() string {
 func (*BlocksSubscriptionNewBlock) ProtoMessage() {}
 
 func (x *BlocksSubscriptionNewBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[4]
+	mi := &file_protobuf_messages_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +504,7 @@ func (x *BlocksSubscriptionNewBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlocksSubscriptionNewBlock.ProtoReflect.Descriptor instead.
 func (*BlocksSubscriptionNewBlock) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{4}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BlocksSubscriptionNewBlock) GetBlock() *Block {
@@ -405,7 +530,7 @@ type BlocksSubscriptionNewTransactions struct {
 
 func (x *BlocksSubscriptionNewTransactions) Reset() {
 	*x = BlocksSubscriptionNewTransactions{}
-	mi := &file_protobuf_messages_proto_msgTypes[5]
+	mi := &file_protobuf_messages_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -417,7 +542,7 @@ func (x *BlocksSubscriptionNewTransactions) String() string {
 func (*BlocksSubscriptionNewTransactions) ProtoMessage() {}
 
 func (x *BlocksSubscriptionNewTransactions) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[5]
+	mi := &file_protobuf_messages_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,7 +555,7 @@ func (x *BlocksSubscriptionNewTransactions) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BlocksSubscriptionNewTransactions.ProtoReflect.Descriptor instead.
 func (*BlocksSubscriptionNewTransactions) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{5}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *BlocksSubscriptionNewTransactions) GetTransactions() []*Transaction {
@@ -453,7 +578,7 @@ type BlocksSubscriptionMessage struct {
 
 func (x *BlocksSubscriptionMessage) Reset() {
 	*x = BlocksSubscriptionMessage{}
-	mi := &file_protobuf_messages_proto_msgTypes[6]
+	mi := &file_protobuf_messages_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -465,7 +590,7 @@ func (x *BlocksSubscriptionMessage) String() string {
 func (*BlocksSubscriptionMessage) ProtoMessage() {}
 
 func (x *BlocksSubscriptionMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[6]
+	mi := &file_protobuf_messages_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +603,7 @@ func (x *BlocksSubscriptionMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlocksSubscriptionMessage.ProtoReflect.Descriptor instead.
 func (*BlocksSubscriptionMessage) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{6}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *BlocksSubscriptionMessage) GetPayload() isBlocksSubscriptionMessage_Payload {
@@ -523,6 +648,170 @@ func (*BlocksSubscriptionMessage_NewBlock) isBlocksSubscriptionMessage_Payload()
 func (*BlocksSubscriptionMessage_NewTransactions) isBlocksSubscriptionMessage_Payload() {}
 
 // Network messages
+type ConnectionsSubscriptionHeartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          *PeerInfo              `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Status        *Status                `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionsSubscriptionHeartbeat) Reset() {
+	*x = ConnectionsSubscriptionHeartbeat{}
+	mi := &file_protobuf_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionsSubscriptionHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionsSubscriptionHeartbeat) ProtoMessage() {}
+
+func (x *ConnectionsSubscriptionHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionsSubscriptionHeartbeat.ProtoReflect.Descriptor instead.
+func (*ConnectionsSubscriptionHeartbeat) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConnectionsSubscriptionHeartbeat) GetPeer() *PeerInfo {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *ConnectionsSubscriptionHeartbeat) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ConnectionsSubscriptionMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ConnectionsSubscriptionMessage_Heartbeat
+	Payload       isConnectionsSubscriptionMessage_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionsSubscriptionMessage) Reset() {
+	*x = ConnectionsSubscriptionMessage{}
+	mi := &file_protobuf_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionsSubscriptionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionsSubscriptionMessage) ProtoMessage() {}
+
+func (x *ConnectionsSubscriptionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionsSubscriptionMessage.ProtoReflect.Descriptor instead.
+func (*ConnectionsSubscriptionMessage) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ConnectionsSubscriptionMessage) GetPayload() isConnectionsSubscriptionMessage_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ConnectionsSubscriptionMessage) GetHeartbeat() *ConnectionsSubscriptionHeartbeat {
+	if x != nil {
+		if x, ok := x.Payload.(*ConnectionsSubscriptionMessage_Heartbeat); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
+
+type isConnectionsSubscriptionMessage_Payload interface {
+	isConnectionsSubscriptionMessage_Payload()
+}
+
+type ConnectionsSubscriptionMessage_Heartbeat struct {
+	Heartbeat *ConnectionsSubscriptionHeartbeat `protobuf:"bytes,1,opt,name=heartbeat,proto3,oneof"`
+}
+
+func (*ConnectionsSubscriptionMessage_Heartbeat) isConnectionsSubscriptionMessage_Payload() {}
+
+// Legacy orders subscription, relaying orders between Pascal nodes
+type LegacyOrdersSubscriptionOrder struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ORDER line as a Pascal node sent it
+	Line          string `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegacyOrdersSubscriptionOrder) Reset() {
+	*x = LegacyOrdersSubscriptionOrder{}
+	mi := &file_protobuf_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegacyOrdersSubscriptionOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyOrdersSubscriptionOrder) ProtoMessage() {}
+
+func (x *LegacyOrdersSubscriptionOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyOrdersSubscriptionOrder.ProtoReflect.Descriptor instead.
+func (*LegacyOrdersSubscriptionOrder) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LegacyOrdersSubscriptionOrder) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
 type NetworkMessageHandshake struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -533,7 +822,7 @@ type NetworkMessageHandshake struct {
 
 func (x *NetworkMessageHandshake) Reset() {
 	*x = NetworkMessageHandshake{}
-	mi := &file_protobuf_messages_proto_msgTypes[7]
+	mi := &file_protobuf_messages_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -545,7 +834,7 @@ func (x *NetworkMessageHandshake) String() string {
 func (*NetworkMessageHandshake) ProtoMessage() {}
 
 func (x *NetworkMessageHandshake) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[7]
+	mi := &file_protobuf_messages_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -558,7 +847,7 @@ func (x *NetworkMessageHandshake) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkMessageHandshake.ProtoReflect.Descriptor instead.
 func (*NetworkMessageHandshake) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{7}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *NetworkMessageHandshake) GetVersion() string {
@@ -585,7 +874,7 @@ type NetworkMessageGetBlocks struct {
 
 func (x *NetworkMessageGetBlocks) Reset() {
 	*x = NetworkMessageGetBlocks{}
-	mi := &file_protobuf_messages_proto_msgTypes[8]
+	mi := &file_protobuf_messages_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -597,7 +886,7 @@ func (x *NetworkMessageGetBlocks) String() string {
 func (*NetworkMessageGetBlocks) ProtoMessage() {}
 
 func (x *NetworkMessageGetBlocks) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[8]
+	mi := &file_protobuf_messages_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +899,7 @@ func (x *NetworkMessageGetBlocks) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkMessageGetBlocks.ProtoReflect.Descriptor instead.
 func (*NetworkMessageGetBlocks) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{8}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *NetworkMessageGetBlocks) GetFromHeight() int64 {
@@ -636,7 +925,7 @@ type NetworkMessageGetBlocksResponse struct {
 
 func (x *NetworkMessageGetBlocksResponse) Reset() {
 	*x = NetworkMessageGetBlocksResponse{}
-	mi := &file_protobuf_messages_proto_msgTypes[9]
+	mi := &file_protobuf_messages_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -648,7 +937,7 @@ func (x *NetworkMessageGetBlocksResponse) String() string {
 func (*NetworkMessageGetBlocksResponse) ProtoMessage() {}
 
 func (x *NetworkMessageGetBlocksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[9]
+	mi := &file_protobuf_messages_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,7 +950,7 @@ func (x *NetworkMessageGetBlocksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkMessageGetBlocksResponse.ProtoReflect.Descriptor instead.
 func (*NetworkMessageGetBlocksResponse) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{9}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NetworkMessageGetBlocksResponse) GetBlocks() []*Block {
@@ -685,7 +974,7 @@ type NetworkMessage struct {
 
 func (x *NetworkMessage) Reset() {
 	*x = NetworkMessage{}
-	mi := &file_protobuf_messages_proto_msgTypes[10]
+	mi := &file_protobuf_messages_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +986,7 @@ func (x *NetworkMessage) String() string {
 func (*NetworkMessage) ProtoMessage() {}
 
 func (x *NetworkMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[10]
+	mi := &file_protobuf_messages_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +999,7 @@ func (x *NetworkMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkMessage.ProtoReflect.Descriptor instead.
 func (*NetworkMessage) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{10}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *NetworkMessage) GetPayload() isNetworkMessage_Payload {
@@ -773,13 +1062,17 @@ func (*NetworkMessage_GetBlocksResponse) isNetworkMessage_Payload() {}
 type DNSPeersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Peers         []*PeerInfo            `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	Total         uint32                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Offset        uint32                 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32                 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DNSPeersResponse) Reset() {
 	*x = DNSPeersResponse{}
-	mi := &file_protobuf_messages_proto_msgTypes[11]
+	mi := &file_protobuf_messages_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -791,7 +1084,7 @@ func (x *DNSPeersResponse) String() string {
 func (*DNSPeersResponse) ProtoMessage() {}
 
 func (x *DNSPeersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_messages_proto_msgTypes[11]
+	mi := &file_protobuf_messages_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1097,7 @@ func (x *DNSPeersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DNSPeersResponse.ProtoReflect.Descriptor instead.
 func (*DNSPeersResponse) Descriptor() ([]byte, []int) {
-	return file_protobuf_messages_proto_rawDescGZIP(), []int{11}
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DNSPeersResponse) GetPeers() []*PeerInfo {
@@ -814,115 +1107,1289 @@ func (x *DNSPeersResponse) GetPeers() []*PeerInfo {
 	return nil
 }
 
-var File_protobuf_messages_proto protoreflect.FileDescriptor
-
-var file_protobuf_messages_proto_rawDesc = string([]byte{
-	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6e, 0x6f, 0x73, 0x6f, 0x67,
-	0x6f, 0x22, 0x44, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
-	0x61, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x09, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61,
-	0x73, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
-	0x61, 0x73, 0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0x97, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a,
-	0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f,
-	0x74, 0x22, 0xf3, 0x01, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76,
-	0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x72,
-	0x69, 0x66, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x22, 0x98, 0x01, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
-	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x7a, 0x0a, 0x1a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x12, 0x23, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x37, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f,
-	0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5c,
-	0x0a, 0x21, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x73, 0x6f,
-	0x67, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
-	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xc1, 0x01, 0x0a,
-	0x19, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x6e, 0x65,
-	0x77, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
-	0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x48, 0x00, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x56, 0x0a,
-	0x10, 0x6e, 0x65, 0x77, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f,
-	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x48, 0x00, 0x52, 0x0f, 0x6e, 0x65, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x22, 0x47, 0x0a, 0x17, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x57, 0x0a, 0x17, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x47, 0x65, 0x74, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x68, 0x65, 0x69,
-	0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x48,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x68, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x6f, 0x48, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x22, 0x48, 0x0a, 0x1f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0xf9, 0x01, 0x0a,
-	0x0e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x3f, 0x0a, 0x09, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68,
-	0x61, 0x6b, 0x65, 0x48, 0x00, 0x52, 0x09, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65,
-	0x12, 0x40, 0x0a, 0x0a, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x4e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x47, 0x65, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x48, 0x00, 0x52, 0x09, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x73, 0x12, 0x59, 0x0a, 0x13, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x27, 0x2e, 0x6e, 0x6f, 0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x11, 0x67, 0x65, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3a, 0x0a, 0x10, 0x44, 0x4e, 0x53, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05,
-	0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f,
-	0x73, 0x6f, 0x67, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x70,
-	0x65, 0x65, 0x72, 0x73, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+func (x *DNSPeersResponse) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
 
-var (
+func (x *DNSPeersResponse) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DNSPeersResponse) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *DNSPeersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DNSRegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          *PeerInfo              `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSRegisterRequest) Reset() {
+	*x = DNSRegisterRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSRegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRegisterRequest) ProtoMessage() {}
+
+func (x *DNSRegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRegisterRequest.ProtoReflect.Descriptor instead.
+func (*DNSRegisterRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DNSRegisterRequest) GetPeer() *PeerInfo {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *DNSRegisterRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DNSRegisterRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *DNSRegisterRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// API
+type APIBlocksStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	LastHash      string                 `protobuf:"bytes,2,opt,name=last_hash,json=lastHash,proto3" json:"last_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIBlocksStatus) Reset() {
+	*x = APIBlocksStatus{}
+	mi := &file_protobuf_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIBlocksStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIBlocksStatus) ProtoMessage() {}
+
+func (x *APIBlocksStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIBlocksStatus.ProtoReflect.Descriptor instead.
+func (*APIBlocksStatus) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *APIBlocksStatus) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *APIBlocksStatus) GetLastHash() string {
+	if x != nil {
+		return x.LastHash
+	}
+	return ""
+}
+
+type APINetworkStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Network        string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Mode           string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	PeerId         string                 `protobuf:"bytes,3,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	ConnectedPeers uint32                 `protobuf:"varint,4,opt,name=connected_peers,json=connectedPeers,proto3" json:"connected_peers,omitempty"`
+	DnsPeers       uint32                 `protobuf:"varint,5,opt,name=dns_peers,json=dnsPeers,proto3" json:"dns_peers,omitempty"`
+	SeedPeers      uint32                 `protobuf:"varint,6,opt,name=seed_peers,json=seedPeers,proto3" json:"seed_peers,omitempty"`
+	NodePeers      uint32                 `protobuf:"varint,7,opt,name=node_peers,json=nodePeers,proto3" json:"node_peers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *APINetworkStatus) Reset() {
+	*x = APINetworkStatus{}
+	mi := &file_protobuf_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APINetworkStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APINetworkStatus) ProtoMessage() {}
+
+func (x *APINetworkStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APINetworkStatus.ProtoReflect.Descriptor instead.
+func (*APINetworkStatus) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *APINetworkStatus) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *APINetworkStatus) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *APINetworkStatus) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *APINetworkStatus) GetConnectedPeers() uint32 {
+	if x != nil {
+		return x.ConnectedPeers
+	}
+	return 0
+}
+
+func (x *APINetworkStatus) GetDnsPeers() uint32 {
+	if x != nil {
+		return x.DnsPeers
+	}
+	return 0
+}
+
+func (x *APINetworkStatus) GetSeedPeers() uint32 {
+	if x != nil {
+		return x.SeedPeers
+	}
+	return 0
+}
+
+func (x *APINetworkStatus) GetNodePeers() uint32 {
+	if x != nil {
+		return x.NodePeers
+	}
+	return 0
+}
+
+type APIBlocks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*Block               `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIBlocks) Reset() {
+	*x = APIBlocks{}
+	mi := &file_protobuf_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIBlocks) ProtoMessage() {}
+
+func (x *APIBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIBlocks.ProtoReflect.Descriptor instead.
+func (*APIBlocks) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *APIBlocks) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type APITransactions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APITransactions) Reset() {
+	*x = APITransactions{}
+	mi := &file_protobuf_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APITransactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APITransactions) ProtoMessage() {}
+
+func (x *APITransactions) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APITransactions.ProtoReflect.Descriptor instead.
+func (*APITransactions) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *APITransactions) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type APIAddressBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Balance       int64                  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Received      uint64                 `protobuf:"varint,3,opt,name=received,proto3" json:"received,omitempty"`
+	Sent          uint64                 `protobuf:"varint,4,opt,name=sent,proto3" json:"sent,omitempty"`
+	Transactions  uint32                 `protobuf:"varint,5,opt,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIAddressBalance) Reset() {
+	*x = APIAddressBalance{}
+	mi := &file_protobuf_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIAddressBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIAddressBalance) ProtoMessage() {}
+
+func (x *APIAddressBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIAddressBalance.ProtoReflect.Descriptor instead.
+func (*APIAddressBalance) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *APIAddressBalance) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *APIAddressBalance) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *APIAddressBalance) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *APIAddressBalance) GetSent() uint64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *APIAddressBalance) GetTransactions() uint32 {
+	if x != nil {
+		return x.Transactions
+	}
+	return 0
+}
+
+type APIAddressTransactions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Transactions  []*Transaction         `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIAddressTransactions) Reset() {
+	*x = APIAddressTransactions{}
+	mi := &file_protobuf_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIAddressTransactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIAddressTransactions) ProtoMessage() {}
+
+func (x *APIAddressTransactions) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIAddressTransactions.ProtoReflect.Descriptor instead.
+func (*APIAddressTransactions) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *APIAddressTransactions) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *APIAddressTransactions) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *APIAddressTransactions) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type APITransactionSubmitted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APITransactionSubmitted) Reset() {
+	*x = APITransactionSubmitted{}
+	mi := &file_protobuf_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APITransactionSubmitted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APITransactionSubmitted) ProtoMessage() {}
+
+func (x *APITransactionSubmitted) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APITransactionSubmitted.ProtoReflect.Descriptor instead.
+func (*APITransactionSubmitted) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *APITransactionSubmitted) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// Multiaddr of a peer to connect to, ending in its ID
+type APIConnectPeer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIConnectPeer) Reset() {
+	*x = APIConnectPeer{}
+	mi := &file_protobuf_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIConnectPeer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIConnectPeer) ProtoMessage() {}
+
+func (x *APIConnectPeer) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIConnectPeer.ProtoReflect.Descriptor instead.
+func (*APIConnectPeer) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *APIConnectPeer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type APIError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIError) Reset() {
+	*x = APIError{}
+	mi := &file_protobuf_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIError) ProtoMessage() {}
+
+func (x *APIError) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIError.ProtoReflect.Descriptor instead.
+func (*APIError) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *APIError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *APIError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+type APIEventBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Transactions  []*Transaction         `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIEventBlock) Reset() {
+	*x = APIEventBlock{}
+	mi := &file_protobuf_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIEventBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIEventBlock) ProtoMessage() {}
+
+func (x *APIEventBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIEventBlock.ProtoReflect.Descriptor instead.
+func (*APIEventBlock) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *APIEventBlock) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *APIEventBlock) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type APIEventReorg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	OldHash       string                 `protobuf:"bytes,2,opt,name=old_hash,json=oldHash,proto3" json:"old_hash,omitempty"`
+	NewHash       string                 `protobuf:"bytes,3,opt,name=new_hash,json=newHash,proto3" json:"new_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIEventReorg) Reset() {
+	*x = APIEventReorg{}
+	mi := &file_protobuf_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIEventReorg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIEventReorg) ProtoMessage() {}
+
+func (x *APIEventReorg) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIEventReorg.ProtoReflect.Descriptor instead.
+func (*APIEventReorg) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *APIEventReorg) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *APIEventReorg) GetOldHash() string {
+	if x != nil {
+		return x.OldHash
+	}
+	return ""
+}
+
+func (x *APIEventReorg) GetNewHash() string {
+	if x != nil {
+		return x.NewHash
+	}
+	return ""
+}
+
+type APIEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*APIEvent_Block
+	//	*APIEvent_Reorg
+	//	*APIEvent_Transaction
+	Payload       isAPIEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIEvent) Reset() {
+	*x = APIEvent{}
+	mi := &file_protobuf_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIEvent) ProtoMessage() {}
+
+func (x *APIEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIEvent.ProtoReflect.Descriptor instead.
+func (*APIEvent) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *APIEvent) GetPayload() isAPIEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *APIEvent) GetBlock() *APIEventBlock {
+	if x != nil {
+		if x, ok := x.Payload.(*APIEvent_Block); ok {
+			return x.Block
+		}
+	}
+	return nil
+}
+
+func (x *APIEvent) GetReorg() *APIEventReorg {
+	if x != nil {
+		if x, ok := x.Payload.(*APIEvent_Reorg); ok {
+			return x.Reorg
+		}
+	}
+	return nil
+}
+
+func (x *APIEvent) GetTransaction() *Transaction {
+	if x != nil {
+		if x, ok := x.Payload.(*APIEvent_Transaction); ok {
+			return x.Transaction
+		}
+	}
+	return nil
+}
+
+type isAPIEvent_Payload interface {
+	isAPIEvent_Payload()
+}
+
+type APIEvent_Block struct {
+	Block *APIEventBlock `protobuf:"bytes,1,opt,name=block,proto3,oneof"`
+}
+
+type APIEvent_Reorg struct {
+	Reorg *APIEventReorg `protobuf:"bytes,2,opt,name=reorg,proto3,oneof"`
+}
+
+type APIEvent_Transaction struct {
+	Transaction *Transaction `protobuf:"bytes,3,opt,name=transaction,proto3,oneof"`
+}
+
+func (*APIEvent_Block) isAPIEvent_Payload() {}
+
+func (*APIEvent_Reorg) isAPIEvent_Payload() {}
+
+func (*APIEvent_Transaction) isAPIEvent_Payload() {}
+
+type APIHeightRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIHeightRequest) Reset() {
+	*x = APIHeightRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIHeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIHeightRequest) ProtoMessage() {}
+
+func (x *APIHeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIHeightRequest.ProtoReflect.Descriptor instead.
+func (*APIHeightRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *APIHeightRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type APIHashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIHashRequest) Reset() {
+	*x = APIHashRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIHashRequest) ProtoMessage() {}
+
+func (x *APIHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIHashRequest.ProtoReflect.Descriptor instead.
+func (*APIHashRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *APIHashRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type APIBlocksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  uint64                 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Zero asks for as many blocks as allowed
+	To            uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIBlocksRequest) Reset() {
+	*x = APIBlocksRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIBlocksRequest) ProtoMessage() {}
+
+func (x *APIBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIBlocksRequest.ProtoReflect.Descriptor instead.
+func (*APIBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *APIBlocksRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *APIBlocksRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type APIAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIAddressRequest) Reset() {
+	*x = APIAddressRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIAddressRequest) ProtoMessage() {}
+
+func (x *APIAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIAddressRequest.ProtoReflect.Descriptor instead.
+func (*APIAddressRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *APIAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type APIAddressTransactionsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Cursor  string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Zero asks for the default page size
+	Limit         uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIAddressTransactionsRequest) Reset() {
+	*x = APIAddressTransactionsRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIAddressTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIAddressTransactionsRequest) ProtoMessage() {}
+
+func (x *APIAddressTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIAddressTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*APIAddressTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *APIAddressTransactionsRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *APIAddressTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *APIAddressTransactionsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type APIEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replays the stored blocks from this height before the live events
+	From *uint64 `protobuf:"varint,1,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	// Only the pending transactions of this address
+	Address       string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIEventsRequest) Reset() {
+	*x = APIEventsRequest{}
+	mi := &file_protobuf_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIEventsRequest) ProtoMessage() {}
+
+func (x *APIEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIEventsRequest.ProtoReflect.Descriptor instead.
+func (*APIEventsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_messages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *APIEventsRequest) GetFrom() uint64 {
+	if x != nil && x.From != nil {
+		return *x.From
+	}
+	return 0
+}
+
+func (x *APIEventsRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+var File_protobuf_messages_proto protoreflect.FileDescriptor
+
+const file_protobuf_messages_proto_rawDesc = "" +
+	"\n" +
+	"\x17protobuf/messages.proto\x12\x06nosogo\x1a\x1bgoogle/protobuf/empty.proto\"D\n" +
+	"\x06Status\x12\x1d\n" +
+	"\n" +
+	"last_block\x18\x01 \x01(\x04R\tlastBlock\x12\x1b\n" +
+	"\tlast_hash\x18\x02 \x01(\tR\blastHash\"\x97\x01\n" +
+	"\x05Block\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12#\n" +
+	"\rprevious_hash\x18\x03 \x01(\tR\fpreviousHash\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1f\n" +
+	"\vmerkle_root\x18\x05 \x01(\tR\n" +
+	"merkleRoot\"\x85\x02\n" +
+	"\vTransaction\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12!\n" +
+	"\fblock_height\x18\x02 \x01(\x04R\vblockHeight\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x04R\x06amount\x12\x17\n" +
+	"\apub_key\x18\x06 \x01(\tR\x06pubKey\x12\x16\n" +
+	"\x06verify\x18\a \x01(\tR\x06verify\x12\x16\n" +
+	"\x06sender\x18\b \x01(\tR\x06sender\x12\x1a\n" +
+	"\breceiver\x18\t \x01(\tR\breceiver\x12\x10\n" +
+	"\x03fee\x18\n" +
+	" \x01(\x04R\x03fee\" \n" +
+	"\fStorageIndex\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\")\n" +
+	"\rIndexesStatus\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\"\xea\x01\n" +
+	"\bPeerInfo\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\tR\x04mode\x12\x1c\n" +
+	"\tconnected\x18\x05 \x01(\bR\tconnected\x12\x1c\n" +
+	"\tdirection\x18\x06 \x01(\tR\tdirection\x12\x1b\n" +
+	"\tlast_seen\x18\a \x01(\x03R\blastSeen\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\b \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05score\x18\t \x01(\x05R\x05score\"z\n" +
+	"\x1aBlocksSubscriptionNewBlock\x12#\n" +
+	"\x05block\x18\x01 \x01(\v2\r.nosogo.BlockR\x05block\x127\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x13.nosogo.TransactionR\ftransactions\"\\\n" +
+	"!BlocksSubscriptionNewTransactions\x127\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x13.nosogo.TransactionR\ftransactions\"\xc1\x01\n" +
+	"\x19BlocksSubscriptionMessage\x12A\n" +
+	"\tnew_block\x18\x01 \x01(\v2\".nosogo.BlocksSubscriptionNewBlockH\x00R\bnewBlock\x12V\n" +
+	"\x10new_transactions\x18\x02 \x01(\v2).nosogo.BlocksSubscriptionNewTransactionsH\x00R\x0fnewTransactionsB\t\n" +
+	"\apayload\"p\n" +
+	" ConnectionsSubscriptionHeartbeat\x12$\n" +
+	"\x04peer\x18\x01 \x01(\v2\x10.nosogo.PeerInfoR\x04peer\x12&\n" +
+	"\x06status\x18\x02 \x01(\v2\x0e.nosogo.StatusR\x06status\"u\n" +
+	"\x1eConnectionsSubscriptionMessage\x12H\n" +
+	"\theartbeat\x18\x01 \x01(\v2(.nosogo.ConnectionsSubscriptionHeartbeatH\x00R\theartbeatB\t\n" +
+	"\apayload\"3\n" +
+	"\x1dLegacyOrdersSubscriptionOrder\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\tR\x04line\"G\n" +
+	"\x17NetworkMessageHandshake\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\"W\n" +
+	"\x17NetworkMessageGetBlocks\x12\x1f\n" +
+	"\vfrom_height\x18\x01 \x01(\x03R\n" +
+	"fromHeight\x12\x1b\n" +
+	"\tto_height\x18\x02 \x01(\x03R\btoHeight\"H\n" +
+	"\x1fNetworkMessageGetBlocksResponse\x12%\n" +
+	"\x06blocks\x18\x01 \x03(\v2\r.nosogo.BlockR\x06blocks\"\xf9\x01\n" +
+	"\x0eNetworkMessage\x12?\n" +
+	"\thandshake\x18\x01 \x01(\v2\x1f.nosogo.NetworkMessageHandshakeH\x00R\thandshake\x12@\n" +
+	"\n" +
+	"get_blocks\x18\x02 \x01(\v2\x1f.nosogo.NetworkMessageGetBlocksH\x00R\tgetBlocks\x12Y\n" +
+	"\x13get_blocks_response\x18\x03 \x01(\v2'.nosogo.NetworkMessageGetBlocksResponseH\x00R\x11getBlocksResponseB\t\n" +
+	"\apayload\"\x9f\x01\n" +
+	"\x10DNSPeersResponse\x12&\n" +
+	"\x05peers\x18\x01 \x03(\v2\x10.nosogo.PeerInfoR\x05peers\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\rR\x05total\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\rR\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\rR\x05limit\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"\x95\x01\n" +
+	"\x12DNSRegisterRequest\x12$\n" +
+	"\x04peer\x18\x01 \x01(\v2\x10.nosogo.PeerInfoR\x04peer\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\"F\n" +
+	"\x0fAPIBlocksStatus\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12\x1b\n" +
+	"\tlast_hash\x18\x02 \x01(\tR\blastHash\"\xdd\x01\n" +
+	"\x10APINetworkStatus\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x17\n" +
+	"\apeer_id\x18\x03 \x01(\tR\x06peerId\x12'\n" +
+	"\x0fconnected_peers\x18\x04 \x01(\rR\x0econnectedPeers\x12\x1b\n" +
+	"\tdns_peers\x18\x05 \x01(\rR\bdnsPeers\x12\x1d\n" +
+	"\n" +
+	"seed_peers\x18\x06 \x01(\rR\tseedPeers\x12\x1d\n" +
+	"\n" +
+	"node_peers\x18\a \x01(\rR\tnodePeers\"2\n" +
+	"\tAPIBlocks\x12%\n" +
+	"\x06blocks\x18\x01 \x03(\v2\r.nosogo.BlockR\x06blocks\"J\n" +
+	"\x0fAPITransactions\x127\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x13.nosogo.TransactionR\ftransactions\"\x9b\x01\n" +
+	"\x11APIAddressBalance\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x03R\abalance\x12\x1a\n" +
+	"\breceived\x18\x03 \x01(\x04R\breceived\x12\x12\n" +
+	"\x04sent\x18\x04 \x01(\x04R\x04sent\x12\"\n" +
+	"\ftransactions\x18\x05 \x01(\rR\ftransactions\"\x8c\x01\n" +
+	"\x16APIAddressTransactions\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x127\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x13.nosogo.TransactionR\ftransactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"-\n" +
+	"\x17APITransactionSubmitted\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"*\n" +
+	"\x0eAPIConnectPeer\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"6\n" +
+	"\bAPIError\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\"m\n" +
+	"\rAPIEventBlock\x12#\n" +
+	"\x05block\x18\x01 \x01(\v2\r.nosogo.BlockR\x05block\x127\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x13.nosogo.TransactionR\ftransactions\"]\n" +
+	"\rAPIEventReorg\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12\x19\n" +
+	"\bold_hash\x18\x02 \x01(\tR\aoldHash\x12\x19\n" +
+	"\bnew_hash\x18\x03 \x01(\tR\anewHash\"\xac\x01\n" +
+	"\bAPIEvent\x12-\n" +
+	"\x05block\x18\x01 \x01(\v2\x15.nosogo.APIEventBlockH\x00R\x05block\x12-\n" +
+	"\x05reorg\x18\x02 \x01(\v2\x15.nosogo.APIEventReorgH\x00R\x05reorg\x127\n" +
+	"\vtransaction\x18\x03 \x01(\v2\x13.nosogo.TransactionH\x00R\vtransactionB\t\n" +
+	"\apayload\"*\n" +
+	"\x10APIHeightRequest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\"$\n" +
+	"\x0eAPIHashRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"6\n" +
+	"\x10APIBlocksRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x04R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x04R\x02to\"-\n" +
+	"\x11APIAddressRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"g\n" +
+	"\x1dAPIAddressTransactionsRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\"N\n" +
+	"\x10APIEventsRequest\x12\x17\n" +
+	"\x04from\x18\x01 \x01(\x04H\x00R\x04from\x88\x01\x01\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddressB\a\n" +
+	"\x05_from2\xf9\x05\n" +
+	"\x03API\x12B\n" +
+	"\x0fGetBlocksStatus\x12\x16.google.protobuf.Empty\x1a\x17.nosogo.APIBlocksStatus\x12D\n" +
+	"\x10GetNetworkStatus\x12\x16.google.protobuf.Empty\x1a\x18.nosogo.APINetworkStatus\x123\n" +
+	"\bGetBlock\x12\x18.nosogo.APIHeightRequest\x1a\r.nosogo.Block\x127\n" +
+	"\x0eGetBlockByHash\x12\x16.nosogo.APIHashRequest\x1a\r.nosogo.Block\x128\n" +
+	"\tGetBlocks\x12\x18.nosogo.APIBlocksRequest\x1a\x11.nosogo.APIBlocks\x12I\n" +
+	"\x14GetBlockTransactions\x12\x18.nosogo.APIHeightRequest\x1a\x17.nosogo.APITransactions\x12=\n" +
+	"\x0eGetTransaction\x12\x16.nosogo.APIHashRequest\x1a\x13.nosogo.Transaction\x12I\n" +
+	"\x11GetAddressBalance\x12\x19.nosogo.APIAddressRequest\x1a\x19.nosogo.APIAddressBalance\x12_\n" +
+	"\x16GetAddressTransactions\x12%.nosogo.APIAddressTransactionsRequest\x1a\x1e.nosogo.APIAddressTransactions\x12I\n" +
+	"\x11SubmitTransaction\x12\x13.nosogo.Transaction\x1a\x1f.nosogo.APITransactionSubmitted\x12?\n" +
+	"\x0fSubscribeEvents\x12\x18.nosogo.APIEventsRequest\x1a\x10.nosogo.APIEvent0\x01B\fZ\n" +
+	"./protobufb\x06proto3"
+
+var (
 	file_protobuf_messages_proto_rawDescOnce sync.Once
 	file_protobuf_messages_proto_rawDescData []byte
 )
@@ -934,37 +2401,96 @@ func file_protobuf_messages_proto_rawDescGZIP() []byte {
 	return file_protobuf_messages_proto_rawDescData
 }
 
-var file_protobuf_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_protobuf_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_protobuf_messages_proto_goTypes = []any{
 	(*Status)(nil),                            // 0: nosogo.Status
 	(*Block)(nil),                             // 1: nosogo.Block
 	(*Transaction)(nil),                       // 2: nosogo.Transaction
-	(*PeerInfo)(nil),                          // 3: nosogo.PeerInfo
-	(*BlocksSubscriptionNewBlock)(nil),        // 4: nosogo.BlocksSubscriptionNewBlock
-	(*BlocksSubscriptionNewTransactions)(nil), // 5: nosogo.BlocksSubscriptionNewTransactions
-	(*BlocksSubscriptionMessage)(nil),         // 6: nosogo.BlocksSubscriptionMessage
-	(*NetworkMessageHandshake)(nil),           // 7: nosogo.NetworkMessageHandshake
-	(*NetworkMessageGetBlocks)(nil),           // 8: nosogo.NetworkMessageGetBlocks
-	(*NetworkMessageGetBlocksResponse)(nil),   // 9: nosogo.NetworkMessageGetBlocksResponse
-	(*NetworkMessage)(nil),                    // 10: nosogo.NetworkMessage
-	(*DNSPeersResponse)(nil),                  // 11: nosogo.DNSPeersResponse
+	(*StorageIndex)(nil),                      // 3: nosogo.StorageIndex
+	(*IndexesStatus)(nil),                     // 4: nosogo.IndexesStatus
+	(*PeerInfo)(nil),                          // 5: nosogo.PeerInfo
+	(*BlocksSubscriptionNewBlock)(nil),        // 6: nosogo.BlocksSubscriptionNewBlock
+	(*BlocksSubscriptionNewTransactions)(nil), // 7: nosogo.BlocksSubscriptionNewTransactions
+	(*BlocksSubscriptionMessage)(nil),         // 8: nosogo.BlocksSubscriptionMessage
+	(*ConnectionsSubscriptionHeartbeat)(nil),  // 9: nosogo.ConnectionsSubscriptionHeartbeat
+	(*ConnectionsSubscriptionMessage)(nil),    // 10: nosogo.ConnectionsSubscriptionMessage
+	(*LegacyOrdersSubscriptionOrder)(nil),     // 11: nosogo.LegacyOrdersSubscriptionOrder
+	(*NetworkMessageHandshake)(nil),           // 12: nosogo.NetworkMessageHandshake
+	(*NetworkMessageGetBlocks)(nil),           // 13: nosogo.NetworkMessageGetBlocks
+	(*NetworkMessageGetBlocksResponse)(nil),   // 14: nosogo.NetworkMessageGetBlocksResponse
+	(*NetworkMessage)(nil),                    // 15: nosogo.NetworkMessage
+	(*DNSPeersResponse)(nil),                  // 16: nosogo.DNSPeersResponse
+	(*DNSRegisterRequest)(nil),                // 17: nosogo.DNSRegisterRequest
+	(*APIBlocksStatus)(nil),                   // 18: nosogo.APIBlocksStatus
+	(*APINetworkStatus)(nil),                  // 19: nosogo.APINetworkStatus
+	(*APIBlocks)(nil),                         // 20: nosogo.APIBlocks
+	(*APITransactions)(nil),                   // 21: nosogo.APITransactions
+	(*APIAddressBalance)(nil),                 // 22: nosogo.APIAddressBalance
+	(*APIAddressTransactions)(nil),            // 23: nosogo.APIAddressTransactions
+	(*APITransactionSubmitted)(nil),           // 24: nosogo.APITransactionSubmitted
+	(*APIConnectPeer)(nil),                    // 25: nosogo.APIConnectPeer
+	(*APIError)(nil),                          // 26: nosogo.APIError
+	(*APIEventBlock)(nil),                     // 27: nosogo.APIEventBlock
+	(*APIEventReorg)(nil),                     // 28: nosogo.APIEventReorg
+	(*APIEvent)(nil),                          // 29: nosogo.APIEvent
+	(*APIHeightRequest)(nil),                  // 30: nosogo.APIHeightRequest
+	(*APIHashRequest)(nil),                    // 31: nosogo.APIHashRequest
+	(*APIBlocksRequest)(nil),                  // 32: nosogo.APIBlocksRequest
+	(*APIAddressRequest)(nil),                 // 33: nosogo.APIAddressRequest
+	(*APIAddressTransactionsRequest)(nil),     // 34: nosogo.APIAddressTransactionsRequest
+	(*APIEventsRequest)(nil),                  // 35: nosogo.APIEventsRequest
+	(*emptypb.Empty)(nil),                     // 36: google.protobuf.Empty
 }
 var file_protobuf_messages_proto_depIdxs = []int32{
 	1,  // 0: nosogo.BlocksSubscriptionNewBlock.block:type_name -> nosogo.Block
 	2,  // 1: nosogo.BlocksSubscriptionNewBlock.transactions:type_name -> nosogo.Transaction
 	2,  // 2: nosogo.BlocksSubscriptionNewTransactions.transactions:type_name -> nosogo.Transaction
-	4,  // 3: nosogo.BlocksSubscriptionMessage.new_block:type_name -> nosogo.BlocksSubscriptionNewBlock
-	5,  // 4: nosogo.BlocksSubscriptionMessage.new_transactions:type_name -> nosogo.BlocksSubscriptionNewTransactions
-	1,  // 5: nosogo.NetworkMessageGetBlocksResponse.blocks:type_name -> nosogo.Block
-	7,  // 6: nosogo.NetworkMessage.handshake:type_name -> nosogo.NetworkMessageHandshake
-	8,  // 7: nosogo.NetworkMessage.get_blocks:type_name -> nosogo.NetworkMessageGetBlocks
-	9,  // 8: nosogo.NetworkMessage.get_blocks_response:type_name -> nosogo.NetworkMessageGetBlocksResponse
-	3,  // 9: nosogo.DNSPeersResponse.peers:type_name -> nosogo.PeerInfo
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	6,  // 3: nosogo.BlocksSubscriptionMessage.new_block:type_name -> nosogo.BlocksSubscriptionNewBlock
+	7,  // 4: nosogo.BlocksSubscriptionMessage.new_transactions:type_name -> nosogo.BlocksSubscriptionNewTransactions
+	5,  // 5: nosogo.ConnectionsSubscriptionHeartbeat.peer:type_name -> nosogo.PeerInfo
+	0,  // 6: nosogo.ConnectionsSubscriptionHeartbeat.status:type_name -> nosogo.Status
+	9,  // 7: nosogo.ConnectionsSubscriptionMessage.heartbeat:type_name -> nosogo.ConnectionsSubscriptionHeartbeat
+	1,  // 8: nosogo.NetworkMessageGetBlocksResponse.blocks:type_name -> nosogo.Block
+	12, // 9: nosogo.NetworkMessage.handshake:type_name -> nosogo.NetworkMessageHandshake
+	13, // 10: nosogo.NetworkMessage.get_blocks:type_name -> nosogo.NetworkMessageGetBlocks
+	14, // 11: nosogo.NetworkMessage.get_blocks_response:type_name -> nosogo.NetworkMessageGetBlocksResponse
+	5,  // 12: nosogo.DNSPeersResponse.peers:type_name -> nosogo.PeerInfo
+	5,  // 13: nosogo.DNSRegisterRequest.peer:type_name -> nosogo.PeerInfo
+	1,  // 14: nosogo.APIBlocks.blocks:type_name -> nosogo.Block
+	2,  // 15: nosogo.APITransactions.transactions:type_name -> nosogo.Transaction
+	2,  // 16: nosogo.APIAddressTransactions.transactions:type_name -> nosogo.Transaction
+	1,  // 17: nosogo.APIEventBlock.block:type_name -> nosogo.Block
+	2,  // 18: nosogo.APIEventBlock.transactions:type_name -> nosogo.Transaction
+	27, // 19: nosogo.APIEvent.block:type_name -> nosogo.APIEventBlock
+	28, // 20: nosogo.APIEvent.reorg:type_name -> nosogo.APIEventReorg
+	2,  // 21: nosogo.APIEvent.transaction:type_name -> nosogo.Transaction
+	36, // 22: nosogo.API.GetBlocksStatus:input_type -> google.protobuf.Empty
+	36, // 23: nosogo.API.GetNetworkStatus:input_type -> google.protobuf.Empty
+	30, // 24: nosogo.API.GetBlock:input_type -> nosogo.APIHeightRequest
+	31, // 25: nosogo.API.GetBlockByHash:input_type -> nosogo.APIHashRequest
+	32, // 26: nosogo.API.GetBlocks:input_type -> nosogo.APIBlocksRequest
+	30, // 27: nosogo.API.GetBlockTransactions:input_type -> nosogo.APIHeightRequest
+	31, // 28: nosogo.API.GetTransaction:input_type -> nosogo.APIHashRequest
+	33, // 29: nosogo.API.GetAddressBalance:input_type -> nosogo.APIAddressRequest
+	34, // 30: nosogo.API.GetAddressTransactions:input_type -> nosogo.APIAddressTransactionsRequest
+	2,  // 31: nosogo.API.SubmitTransaction:input_type -> nosogo.Transaction
+	35, // 32: nosogo.API.SubscribeEvents:input_type -> nosogo.APIEventsRequest
+	18, // 33: nosogo.API.GetBlocksStatus:output_type -> nosogo.APIBlocksStatus
+	19, // 34: nosogo.API.GetNetworkStatus:output_type -> nosogo.APINetworkStatus
+	1,  // 35: nosogo.API.GetBlock:output_type -> nosogo.Block
+	1,  // 36: nosogo.API.GetBlockByHash:output_type -> nosogo.Block
+	20, // 37: nosogo.API.GetBlocks:output_type -> nosogo.APIBlocks
+	21, // 38: nosogo.API.GetBlockTransactions:output_type -> nosogo.APITransactions
+	2,  // 39: nosogo.API.GetTransaction:output_type -> nosogo.Transaction
+	22, // 40: nosogo.API.GetAddressBalance:output_type -> nosogo.APIAddressBalance
+	23, // 41: nosogo.API.GetAddressTransactions:output_type -> nosogo.APIAddressTransactions
+	24, // 42: nosogo.API.SubmitTransaction:output_type -> nosogo.APITransactionSubmitted
+	29, // 43: nosogo.API.SubscribeEvents:output_type -> nosogo.APIEvent
+	33, // [33:44] is the sub-list for method output_type
+	22, // [22:33] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_protobuf_messages_proto_init() }
@@ -972,24 +2498,33 @@ func file_protobuf_messages_proto_init() {
 	if File_protobuf_messages_proto != nil {
 		return
 	}
-	file_protobuf_messages_proto_msgTypes[6].OneofWrappers = []any{
+	file_protobuf_messages_proto_msgTypes[8].OneofWrappers = []any{
 		(*BlocksSubscriptionMessage_NewBlock)(nil),
 		(*BlocksSubscriptionMessage_NewTransactions)(nil),
 	}
 	file_protobuf_messages_proto_msgTypes[10].OneofWrappers = []any{
+		(*ConnectionsSubscriptionMessage_Heartbeat)(nil),
+	}
+	file_protobuf_messages_proto_msgTypes[15].OneofWrappers = []any{
 		(*NetworkMessage_Handshake)(nil),
 		(*NetworkMessage_GetBlocks)(nil),
 		(*NetworkMessage_GetBlocksResponse)(nil),
 	}
+	file_protobuf_messages_proto_msgTypes[29].OneofWrappers = []any{
+		(*APIEvent_Block)(nil),
+		(*APIEvent_Reorg)(nil),
+		(*APIEvent_Transaction)(nil),
+	}
+	file_protobuf_messages_proto_msgTypes[35].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protobuf_messages_proto_rawDesc), len(file_protobuf_messages_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   36,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_protobuf_messages_proto_goTypes,
 		DependencyIndexes: file_protobuf_messages_proto_depIdxs,
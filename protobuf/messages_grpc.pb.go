@@ -0,0 +1,510 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: protobuf/messages.proto
+
+package protobuf
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	API_GetBlocksStatus_FullMethodName        = "/nosogo.API/GetBlocksStatus"
+	API_GetNetworkStatus_FullMethodName       = "/nosogo.API/GetNetworkStatus"
+	API_GetBlock_FullMethodName               = "/nosogo.API/GetBlock"
+	API_GetBlockByHash_FullMethodName         = "/nosogo.API/GetBlockByHash"
+	API_GetBlocks_FullMethodName              = "/nosogo.API/GetBlocks"
+	API_GetBlockTransactions_FullMethodName   = "/nosogo.API/GetBlockTransactions"
+	API_GetTransaction_FullMethodName         = "/nosogo.API/GetTransaction"
+	API_GetAddressBalance_FullMethodName      = "/nosogo.API/GetAddressBalance"
+	API_GetAddressTransactions_FullMethodName = "/nosogo.API/GetAddressTransactions"
+	API_SubmitTransaction_FullMethodName      = "/nosogo.API/SubmitTransaction"
+	API_SubscribeEvents_FullMethodName        = "/nosogo.API/SubscribeEvents"
+)
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Mirrors the REST API
+type APIClient interface {
+	GetBlocksStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*APIBlocksStatus, error)
+	GetNetworkStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*APINetworkStatus, error)
+	GetBlock(ctx context.Context, in *APIHeightRequest, opts ...grpc.CallOption) (*Block, error)
+	GetBlockByHash(ctx context.Context, in *APIHashRequest, opts ...grpc.CallOption) (*Block, error)
+	GetBlocks(ctx context.Context, in *APIBlocksRequest, opts ...grpc.CallOption) (*APIBlocks, error)
+	GetBlockTransactions(ctx context.Context, in *APIHeightRequest, opts ...grpc.CallOption) (*APITransactions, error)
+	GetTransaction(ctx context.Context, in *APIHashRequest, opts ...grpc.CallOption) (*Transaction, error)
+	GetAddressBalance(ctx context.Context, in *APIAddressRequest, opts ...grpc.CallOption) (*APIAddressBalance, error)
+	GetAddressTransactions(ctx context.Context, in *APIAddressTransactionsRequest, opts ...grpc.CallOption) (*APIAddressTransactions, error)
+	SubmitTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*APITransactionSubmitted, error)
+	SubscribeEvents(ctx context.Context, in *APIEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[APIEvent], error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) GetBlocksStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*APIBlocksStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIBlocksStatus)
+	err := c.cc.Invoke(ctx, API_GetBlocksStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetNetworkStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*APINetworkStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APINetworkStatus)
+	err := c.cc.Invoke(ctx, API_GetNetworkStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlock(ctx context.Context, in *APIHeightRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, API_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlockByHash(ctx context.Context, in *APIHashRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, API_GetBlockByHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlocks(ctx context.Context, in *APIBlocksRequest, opts ...grpc.CallOption) (*APIBlocks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIBlocks)
+	err := c.cc.Invoke(ctx, API_GetBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlockTransactions(ctx context.Context, in *APIHeightRequest, opts ...grpc.CallOption) (*APITransactions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APITransactions)
+	err := c.cc.Invoke(ctx, API_GetBlockTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetTransaction(ctx context.Context, in *APIHashRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, API_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetAddressBalance(ctx context.Context, in *APIAddressRequest, opts ...grpc.CallOption) (*APIAddressBalance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIAddressBalance)
+	err := c.cc.Invoke(ctx, API_GetAddressBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetAddressTransactions(ctx context.Context, in *APIAddressTransactionsRequest, opts ...grpc.CallOption) (*APIAddressTransactions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIAddressTransactions)
+	err := c.cc.Invoke(ctx, API_GetAddressTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubmitTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*APITransactionSubmitted, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APITransactionSubmitted)
+	err := c.cc.Invoke(ctx, API_SubmitTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubscribeEvents(ctx context.Context, in *APIEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[APIEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[APIEventsRequest, APIEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_SubscribeEventsClient = grpc.ServerStreamingClient[APIEvent]
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility.
+//
+// Mirrors the REST API
+type APIServer interface {
+	GetBlocksStatus(context.Context, *emptypb.Empty) (*APIBlocksStatus, error)
+	GetNetworkStatus(context.Context, *emptypb.Empty) (*APINetworkStatus, error)
+	GetBlock(context.Context, *APIHeightRequest) (*Block, error)
+	GetBlockByHash(context.Context, *APIHashRequest) (*Block, error)
+	GetBlocks(context.Context, *APIBlocksRequest) (*APIBlocks, error)
+	GetBlockTransactions(context.Context, *APIHeightRequest) (*APITransactions, error)
+	GetTransaction(context.Context, *APIHashRequest) (*Transaction, error)
+	GetAddressBalance(context.Context, *APIAddressRequest) (*APIAddressBalance, error)
+	GetAddressTransactions(context.Context, *APIAddressTransactionsRequest) (*APIAddressTransactions, error)
+	SubmitTransaction(context.Context, *Transaction) (*APITransactionSubmitted, error)
+	SubscribeEvents(*APIEventsRequest, grpc.ServerStreamingServer[APIEvent]) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAPIServer struct{}
+
+func (UnimplementedAPIServer) GetBlocksStatus(context.Context, *emptypb.Empty) (*APIBlocksStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlocksStatus not implemented")
+}
+func (UnimplementedAPIServer) GetNetworkStatus(context.Context, *emptypb.Empty) (*APINetworkStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkStatus not implemented")
+}
+func (UnimplementedAPIServer) GetBlock(context.Context, *APIHeightRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedAPIServer) GetBlockByHash(context.Context, *APIHashRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHash not implemented")
+}
+func (UnimplementedAPIServer) GetBlocks(context.Context, *APIBlocksRequest) (*APIBlocks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (UnimplementedAPIServer) GetBlockTransactions(context.Context, *APIHeightRequest) (*APITransactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockTransactions not implemented")
+}
+func (UnimplementedAPIServer) GetTransaction(context.Context, *APIHashRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedAPIServer) GetAddressBalance(context.Context, *APIAddressRequest) (*APIAddressBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressBalance not implemented")
+}
+func (UnimplementedAPIServer) GetAddressTransactions(context.Context, *APIAddressTransactionsRequest) (*APIAddressTransactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressTransactions not implemented")
+}
+func (UnimplementedAPIServer) SubmitTransaction(context.Context, *Transaction) (*APITransactionSubmitted, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTransaction not implemented")
+}
+func (UnimplementedAPIServer) SubscribeEvents(*APIEventsRequest, grpc.ServerStreamingServer[APIEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+func (UnimplementedAPIServer) testEmbeddedByValue()             {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	// If the following call pancis, it indicates UnimplementedAPIServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_GetBlocksStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlocksStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetBlocksStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlocksStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetNetworkStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetNetworkStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetNetworkStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetNetworkStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlock(ctx, req.(*APIHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlockByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetBlockByHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlockByHash(ctx, req.(*APIHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlocks(ctx, req.(*APIBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlockTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlockTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetBlockTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlockTransactions(ctx, req.(*APIHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetTransaction(ctx, req.(*APIHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetAddressBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetAddressBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetAddressBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetAddressBalance(ctx, req.(*APIAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetAddressTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIAddressTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetAddressTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetAddressTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetAddressTransactions(ctx, req.(*APIAddressTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubmitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Transaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SubmitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_SubmitTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SubmitTransaction(ctx, req.(*Transaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(APIEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeEvents(m, &grpc.GenericServerStream[APIEventsRequest, APIEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_SubscribeEventsServer = grpc.ServerStreamingServer[APIEvent]
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nosogo.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlocksStatus",
+			Handler:    _API_GetBlocksStatus_Handler,
+		},
+		{
+			MethodName: "GetNetworkStatus",
+			Handler:    _API_GetNetworkStatus_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _API_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockByHash",
+			Handler:    _API_GetBlockByHash_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _API_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetBlockTransactions",
+			Handler:    _API_GetBlockTransactions_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _API_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetAddressBalance",
+			Handler:    _API_GetAddressBalance_Handler,
+		},
+		{
+			MethodName: "GetAddressTransactions",
+			Handler:    _API_GetAddressTransactions_Handler,
+		},
+		{
+			MethodName: "SubmitTransaction",
+			Handler:    _API_SubmitTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _API_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protobuf/messages.proto",
+}
@@ -0,0 +1,174 @@
+package protobuf
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrNotAcceptable        = errors.New("none of the accepted content types can be served")
+	ErrUnsupportedMediaType = errors.New("unsupported content type")
+)
+
+// Media types we understand and the content type they map to
+var mediaTypes = map[string]string{
+	"application/json":                ContentTypeJSON,
+	"application/x-protobuf":          ContentTypeProtoBuf,
+	"application/protobuf":            ContentTypeProtoBuf,
+	"application/vnd.google.protobuf": ContentTypeProtoBuf,
+	"text/plain":                      ContentTypeText,
+}
+
+// Content types we serve, the first one preferred when the client doesn't mind
+var contentTypes = []string{ContentTypeJSON, ContentTypeProtoBuf, ContentTypeText}
+
+// A media range of an Accept header
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// How closely a media range matches a media type: exact over type/* over */*,
+// -1 when it doesn't match
+func (mr mediaRange) specificity(mediaType string) int {
+	switch {
+	case mr.mediaType == mediaType:
+		return 2
+	case strings.HasSuffix(mr.mediaType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mr.mediaType, "*")):
+		return 1
+	case mr.mediaType == "*/*":
+		return 0
+	}
+	return -1
+}
+
+// Negotiate picks the content type to answer with from the Accept header.
+// Each content type gets the quality of the most specific range matching it,
+// as in RFC 9110 section 12.5.1, so a q=0 on it refuses it whatever the wildcards.
+// On a tie the more specific match wins, then the first content type we serve.
+func Negotiate(r *http.Request) (string, error) {
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return ContentTypeJSON, nil
+	}
+
+	var ranges []mediaRange
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	var (
+		best            string
+		bestQuality     float64
+		bestSpecificity = -1
+	)
+	for _, contentType := range contentTypes {
+		quality, specificity := 0.0, -1
+		for mediaType, served := range mediaTypes {
+			if served != contentType {
+				continue
+			}
+			for _, mr := range ranges {
+				s := mr.specificity(mediaType)
+				if s > specificity || (s == specificity && mr.quality > quality) {
+					quality, specificity = mr.quality, s
+				}
+			}
+		}
+
+		if specificity < 0 || quality == 0 {
+			continue
+		}
+		if quality > bestQuality || (quality == bestQuality && specificity > bestSpecificity) {
+			best, bestQuality, bestSpecificity = contentType, quality, specificity
+		}
+	}
+
+	if best == "" {
+		return "", ErrNotAcceptable
+	}
+
+	return best, nil
+}
+
+// WriteNegotiated writes a message in the content type asked for by the client
+func WriteNegotiated(w http.ResponseWriter, r *http.Request, msg proto.Message) {
+	w.Header().Add("Vary", "Accept")
+
+	contentType, err := Negotiate(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+
+	Write(w, contentType, msg)
+}
+
+// WriteNegotiatedStatus writes a message with a status other than 200 OK
+func WriteNegotiatedStatus(w http.ResponseWriter, r *http.Request, status int, msg proto.Message) {
+	WriteNegotiated(&statusWriter{ResponseWriter: w, status: status}, r, msg)
+}
+
+// Sends its status right before the first write of the body
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.wroteHeader = true
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(data []byte) (int, error) {
+	if !sw.wroteHeader {
+		sw.WriteHeader(sw.status)
+	}
+	return sw.ResponseWriter.Write(data)
+}
+
+// ReadMessage reads a message in the content type sent by the client
+func ReadMessage(w http.ResponseWriter, r *http.Request, maxSize int64, msg proto.Message) error {
+	contentType := ContentTypeJSON
+	if header := r.Header.Get("Content-Type"); header != "" {
+		mediaType, _, err := mime.ParseMediaType(header)
+		if err != nil {
+			return ErrUnsupportedMediaType
+		}
+		if contentType = mediaTypes[mediaType]; contentType == "" || strings.Contains(mediaType, "*") {
+			return ErrUnsupportedMediaType
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		return err
+	}
+
+	switch contentType {
+	case ContentTypeProtoBuf:
+		return proto.Unmarshal(data, msg)
+	case ContentTypeText:
+		return prototext.Unmarshal(data, msg)
+	default:
+		return UnmarshalJSON(data, msg)
+	}
+}
@@ -1,11 +1,9 @@
 package protobuf
 
 import (
-	"encoding/json"
-	"fmt"
-	"maps"
 	"net/http"
-	reflect "reflect"
+	"slices"
+	"strings"
 	"sync"
 
 	"google.golang.org/protobuf/proto"
@@ -58,6 +56,82 @@ func (pl *PeerList) Connected(id string) bool {
 	return ok && peer.Connected
 }
 
+// Get returns a copy of the peer with the given ID
+func (pl *PeerList) Get(id string) (*PeerInfo, bool) {
+	pl.mu.RLock()
+	defer pl.mu.RUnlock()
+
+	peer, ok := pl.peers[id]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(peer).(*PeerInfo), true
+}
+
+// Has returns true if the peer with given ID is on the list
+func (pl *PeerList) Has(id string) bool {
+	pl.mu.RLock()
+	defer pl.mu.RUnlock()
+
+	_, ok := pl.peers[id]
+	return ok
+}
+
+// Len returns the number of peers on the list
+func (pl *PeerList) Len() int {
+	pl.mu.RLock()
+	defer pl.mu.RUnlock()
+
+	return len(pl.peers)
+}
+
+// Connect marks a peer as connected, sets its direction and last seen time
+func (pl *PeerList) Connect(id string, direction string, seen int64) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if peer, ok := pl.peers[id]; ok {
+		peer.Connected = true
+		peer.Direction = direction
+		peer.LastSeen = seen
+	}
+}
+
+// Seen updates the last seen time of a peer
+func (pl *PeerList) Seen(id string, seen int64) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if peer, ok := pl.peers[id]; ok {
+		peer.LastSeen = seen
+	}
+}
+
+// SetLatency records the latency measured to a peer, in milliseconds
+func (pl *PeerList) SetLatency(id string, latency int64) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if peer, ok := pl.peers[id]; ok {
+		peer.LatencyMs = latency
+	}
+}
+
+// RemoveStale deletes all peers not seen since `before` and returns their IDs
+func (pl *PeerList) RemoveStale(before int64) []string {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	var removed []string
+	for id, peer := range pl.peers {
+		if peer.LastSeen < before {
+			delete(pl.peers, id)
+			removed = append(removed, id)
+		}
+	}
+	return removed
+}
+
 // Disconnect marks a peer as disconnected and clears its direction
 func (pl *PeerList) Disconnect(id string) {
 	pl.mu.Lock()
@@ -74,8 +148,11 @@ func (pl *PeerList) Peers() map[string]*PeerInfo {
 	pl.mu.RLock()
 	defer pl.mu.RUnlock()
 
-	// Shallow copy — safe because PeerInfo pointers are stable
-	clone := maps.Clone(pl.peers)
+	// Deep copy, Connect, Seen and the like change the peers under the lock
+	clone := make(map[string]*PeerInfo, len(pl.peers))
+	for id, peer := range pl.peers {
+		clone[id] = proto.Clone(peer).(*PeerInfo)
+	}
 	return clone
 }
 
@@ -89,42 +166,31 @@ func (pl *PeerList) Peers() map[string]*PeerInfo {
 // 	}
 // }
 
-// Helper to write JSON response
-func (pl *PeerList) WriteJSON(w http.ResponseWriter) {
+// Response returns a snapshot of the list as a DNSPeersResponse, sorted by ID
+func (pl *PeerList) Response() *DNSPeersResponse {
 	pl.mu.RLock()
 	defer pl.mu.RUnlock()
 
 	msg := &DNSPeersResponse{}
 
+	// Deep copy, the peers may change while the response is being written
 	for _, peer := range pl.peers {
-		msg.Peers = append(msg.Peers, peer)
-	}
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(msg); err != nil {
-		http.Error(w, "failed to encode to JSON", http.StatusInternalServerError)
+		msg.Peers = append(msg.Peers, proto.Clone(peer).(*PeerInfo))
 	}
+	slices.SortFunc(msg.Peers, func(a, b *PeerInfo) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	msg.Total = uint32(len(msg.Peers))
+
+	return msg
+}
+
+// Helper to write JSON response
+func (pl *PeerList) WriteJSON(w http.ResponseWriter) {
+	WriteJSON(w, pl.Response())
 }
 
 // Helper to write ProtoBuf response
 func (pl *PeerList) WriteProtobuf(w http.ResponseWriter) {
-	pl.mu.RLock()
-	defer pl.mu.RUnlock()
-
-	msg := &DNSPeersResponse{}
-
-	for _, peer := range pl.peers {
-		msg.Peers = append(msg.Peers, peer)
-	}
-
-	msgType := reflect.TypeOf(msg).Elem()
-
-	protoMime := fmt.Sprintf("application/x-protobuf; proto=%s.%s", msgType.PkgPath(), msgType.Name())
-	w.Header().Set("Content-Type", protoMime)
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	data, err := proto.Marshal(msg)
-	if err != nil {
-		http.Error(w, "failed to marshal protobuf", http.StatusInternalServerError)
-		return
-	}
-	w.Write(data)
+	WriteProtoBuf(w, pl.Response())
 }
@@ -0,0 +1,191 @@
+package protobuf
+
+import (
+	"cmp"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Peer sorting and filtering constants
+const (
+	PeerSortID       = "id"
+	PeerSortLastSeen = "last_seen"
+	PeerSortAddress  = "address"
+
+	PeerFamilyIPv4 = "ipv4"
+	PeerFamilyIPv6 = "ipv6"
+
+	DefaultPeerQueryLimit = 100
+	MaxPeerQueryLimit     = 1000
+)
+
+var (
+	ErrInvalidPeerQuery  = errors.New("invalid peer query")
+	ErrInvalidPeerCursor = errors.New("invalid peer cursor")
+)
+
+// PeerQuery filters, sorts and pages a PeerList
+type PeerQuery struct {
+	Mode       string
+	Connected  *bool
+	Direction  string
+	Family     string
+	Sort       string
+	Descending bool
+	Offset     int
+	Limit      int
+	Cursor     string
+}
+
+// Validate checks the query and fills in the defaults
+func (q *PeerQuery) Validate() error {
+	switch q.Sort {
+	case "":
+		q.Sort = PeerSortID
+	case PeerSortID, PeerSortLastSeen, PeerSortAddress:
+	default:
+		return fmt.Errorf("%w: unknown sort '%s'", ErrInvalidPeerQuery, q.Sort)
+	}
+
+	switch q.Direction {
+	case "", DirectionInbound, DirectionOutbound:
+	default:
+		return fmt.Errorf("%w: unknown direction '%s'", ErrInvalidPeerQuery, q.Direction)
+	}
+
+	switch q.Family {
+	case "", PeerFamilyIPv4, PeerFamilyIPv6:
+	default:
+		return fmt.Errorf("%w: unknown address family '%s'", ErrInvalidPeerQuery, q.Family)
+	}
+
+	if q.Limit == 0 {
+		q.Limit = DefaultPeerQueryLimit
+	}
+	if q.Limit < 0 || q.Limit > MaxPeerQueryLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidPeerQuery, MaxPeerQueryLimit)
+	}
+	if q.Offset < 0 {
+		return fmt.Errorf("%w: offset can't be negative", ErrInvalidPeerQuery)
+	}
+	if q.Offset > 0 && q.Cursor != "" {
+		return fmt.Errorf("%w: use either offset or cursor", ErrInvalidPeerQuery)
+	}
+
+	return nil
+}
+
+// Query returns the page of peers matching the query
+func (pl *PeerList) Query(q PeerQuery) (*DNSPeersResponse, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var after *PeerInfo
+	if q.Cursor != "" {
+		var err error
+		if after, err = decodePeerCursor(q.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	peers := pl.Response().Peers
+	peers = slices.DeleteFunc(peers, func(peer *PeerInfo) bool {
+		return !q.matches(peer)
+	})
+	slices.SortFunc(peers, q.compare)
+
+	msg := &DNSPeersResponse{
+		Total:  uint32(len(peers)),
+		Offset: uint32(q.Offset),
+		Limit:  uint32(q.Limit),
+	}
+
+	start := min(q.Offset, len(peers))
+	if after != nil {
+		start, _ = slices.BinarySearchFunc(peers, after, func(peer, target *PeerInfo) int {
+			// Land right after the peer the cursor points to
+			if q.compare(peer, target) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		msg.Offset = uint32(start)
+	}
+	end := min(start+q.Limit, len(peers))
+	msg.Peers = peers[start:end]
+
+	if end < len(peers) {
+		msg.NextCursor = encodePeerCursor(peers[end-1])
+	}
+
+	return msg, nil
+}
+
+// Checks if a peer passes the filters of the query
+func (q *PeerQuery) matches(peer *PeerInfo) bool {
+	if q.Mode != "" && peer.Mode != q.Mode {
+		return false
+	}
+	if q.Connected != nil && peer.Connected != *q.Connected {
+		return false
+	}
+	if q.Direction != "" && peer.Direction != q.Direction {
+		return false
+	}
+	if q.Family != "" {
+		ip := net.ParseIP(peer.Address)
+		if ip == nil {
+			return false
+		}
+		isIPv4 := ip.To4() != nil
+		if isIPv4 != (q.Family == PeerFamilyIPv4) {
+			return false
+		}
+	}
+	return true
+}
+
+// Orders peers by the query sort, with the ID breaking ties
+func (q *PeerQuery) compare(a, b *PeerInfo) int {
+	var c int
+	switch q.Sort {
+	case PeerSortLastSeen:
+		c = cmp.Compare(a.LastSeen, b.LastSeen)
+	case PeerSortAddress:
+		c = cmp.Or(cmp.Compare(a.Address, b.Address), cmp.Compare(a.Port, b.Port))
+	}
+	c = cmp.Or(c, cmp.Compare(a.Id, b.Id))
+	if q.Descending {
+		return -c
+	}
+	return c
+}
+
+// A cursor holds the sort keys of the last peer on a page
+func encodePeerCursor(peer *PeerInfo) string {
+	data, _ := proto.Marshal(&PeerInfo{
+		Address:  peer.Address,
+		Port:     peer.Port,
+		Id:       peer.Id,
+		LastSeen: peer.LastSeen,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePeerCursor(cursor string) (*PeerInfo, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidPeerCursor
+	}
+	peer := &PeerInfo{}
+	if err := proto.Unmarshal(data, peer); err != nil || peer.Id == "" {
+		return nil, ErrInvalidPeerCursor
+	}
+	return peer, nil
+}
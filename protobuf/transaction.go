@@ -3,8 +3,11 @@ package protobuf
 import (
 	"crypto"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
 )
 
 // Creates a new transaction
@@ -21,7 +24,8 @@ func NewTransaction(
 	return transaction, nil
 }
 
-// Sets the Hash field of the transaction
+// Sets the Hash field of the transaction. The amount is left out to keep the
+// hashes already stored, the signature in Verify covers it.
 func (t *Transaction) SetHash() error {
 	// TODO: Get more values in here
 	value := fmt.Sprintf(
@@ -42,3 +46,91 @@ func (t *Transaction) SetHash() error {
 	t.Hash = "T" + strings.ToUpper(hex.EncodeToString(h.Sum([]byte(salt))))
 	return nil
 }
+
+const (
+	// TransactionTypeTransfer moves coins between addresses
+	TransactionTypeTransfer = "TRFR"
+	// TransactionTypeCoinbase pays the miner the block reward and the fees
+	TransactionTypeCoinbase = "COINBASE"
+	// TransactionTypePoS pays a proof of stake reward, legacy blocks only
+	TransactionTypePoS = "POS"
+	// TransactionTypeMN pays a masternode reward
+	TransactionTypeMN = "MN"
+
+	// How far a submitted transaction's timestamp may be from our clock, in seconds
+	cTransactionMaxSkew = 10 * 60
+)
+
+// ErrTransactionKnown is returned when a submitted transaction was already seen
+var ErrTransactionKnown = errors.New("transaction already known")
+
+// TransactionError tells which field of a transaction is wrong and why
+type TransactionError struct {
+	Field  string
+	Reason string
+}
+
+func (e *TransactionError) Error() string {
+	return fmt.Sprintf("invalid transaction %s: %s", e.Field, e.Reason)
+}
+
+// SigningPayload returns what the sender signs, everything but the hash and the signature
+func (t *Transaction) SigningPayload() string {
+	return fmt.Sprintf(
+		"%s:%d:%s:%s:%s:%d",
+		t.Type,
+		t.Timestamp,
+		t.PubKey,
+		t.Sender,
+		t.Receiver,
+		t.Amount,
+	)
+}
+
+// Sign fills in the public key, the signature and the hash
+func (t *Transaction) Sign(privateKey string, publicKey string) error {
+	t.PubKey = publicKey
+	signature, err := legacy.SignMessage(privateKey, t.SigningPayload())
+	if err != nil {
+		return err
+	}
+	t.Verify = signature
+	return t.SetHash()
+}
+
+// ValidatePending checks a signed transaction waiting to get into a block
+func (t *Transaction) ValidatePending(now int64) error {
+	switch {
+	case t.BlockHeight != 0:
+		return &TransactionError{"block_height", "must be zero for a pending transaction"}
+	case t.Type != TransactionTypeTransfer:
+		return &TransactionError{"type", "must be " + TransactionTypeTransfer}
+	case t.Amount == 0:
+		return &TransactionError{"amount", "must be more than zero"}
+	// Neither signed nor hashed, fees only come from legacy orders
+	case t.Fee != 0:
+		return &TransactionError{"fee", "must be zero"}
+	case t.Timestamp < now-cTransactionMaxSkew || t.Timestamp > now+cTransactionMaxSkew:
+		return &TransactionError{"timestamp", "is too far from the current time"}
+	case !legacy.IsValidHashAddress(t.Sender):
+		return &TransactionError{"sender", "is not a valid address"}
+	case !legacy.IsValidHashAddress(t.Receiver):
+		return &TransactionError{"receiver", "is not a valid address"}
+	case t.Sender == t.Receiver:
+		return &TransactionError{"receiver", "is the same as the sender"}
+	case !legacy.IsAddressOfPublicKey(t.Sender, t.PubKey):
+		return &TransactionError{"pub_key", "does not match the sender"}
+	case !legacy.VerifyMessage(t.PubKey, t.SigningPayload(), t.Verify):
+		return &TransactionError{"verify", "is not a valid signature"}
+	}
+
+	hash := t.Hash
+	if err := t.SetHash(); err != nil {
+		return err
+	}
+	if hash != "" && hash != t.Hash {
+		return &TransactionError{"hash", "does not match the transaction"}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// AddressTotals sums up the confirmed transactions of an address,
+// what was sent includes the fees paid
+type AddressTotals struct {
+	Received     uint64
+	Sent         uint64
+	Transactions uint32
+}
+
+// GetAddressTotals adds up what an address received and sent
+func (sm *StorageManager) GetAddressTotals(address string) (AddressTotals, error) {
+	var totals AddressTotals
+	err := sm.forEachAddressTransaction(address, func(transaction *pb.Transaction) error {
+		totals.Transactions++
+		if transaction.Receiver == address {
+			totals.Received += transaction.Amount
+		}
+		if transaction.Sender == address {
+			totals.Sent += transaction.Amount + transaction.Fee
+		}
+		return nil
+	})
+	return totals, err
+}
+
+// GetAddressTransactions returns up to `limit` transactions of an address, newest first,
+// starting after the cursor. The returned cursor is empty on the last page.
+func (sm *StorageManager) GetAddressTransactions(address string, cursor string, limit int) ([]*pb.Transaction, string, error) {
+	prefix := AddressPrefix + address + ":"
+	keyRange := util.BytesPrefix([]byte(prefix))
+	if cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || !strings.HasPrefix(string(after), prefix) {
+			return nil, "", ErrInvalidCursor
+		}
+		// Walking backwards, the cursor is where the next page ends
+		keyRange.Limit = after
+	}
+
+	iter := sm.db.NewIterator(keyRange, nil)
+	defer iter.Release()
+
+	var (
+		transactions []*pb.Transaction
+		lastKey      []byte
+		more         bool
+	)
+	for ok := iter.Last(); ok; ok = iter.Prev() {
+		if len(transactions) == limit {
+			more = true
+			break
+		}
+
+		transaction, err := sm.indexedTransaction(iter.Value())
+		if err != nil {
+			return nil, "", err
+		}
+		transactions = append(transactions, transaction)
+		lastKey = slices.Clone(iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		return nil, "", err
+	}
+
+	// The cursor is the last key handed out
+	if more {
+		return transactions, base64.RawURLEncoding.EncodeToString(lastKey), nil
+	}
+	return transactions, "", nil
+}
+
+func (sm *StorageManager) forEachAddressTransaction(address string, fn func(*pb.Transaction) error) error {
+	iter := sm.db.NewIterator(util.BytesPrefix([]byte(AddressPrefix+address+":")), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		transaction, err := sm.indexedTransaction(iter.Value())
+		if err != nil {
+			return err
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+// Follows an index entry to its transaction
+func (sm *StorageManager) indexedTransaction(data []byte) (*pb.Transaction, error) {
+	index := &pb.StorageIndex{}
+	if err := proto.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal index: %w", err)
+	}
+
+	transaction := &pb.Transaction{}
+	if err := sm.TransactionStorage().Get(index.Key, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
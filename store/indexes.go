@@ -0,0 +1,339 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// IndexesVersion is bumped whenever the indexes change, so databases built
+// before are re-indexed
+const IndexesVersion = 2
+
+// PutBlock stores a block with its transactions and indexes them by hash, in one write
+func (sm *StorageManager) PutBlock(block *pb.Block, transactions []*pb.Transaction) error {
+	batch := new(leveldb.Batch)
+	if err := sm.putBlock(batch, block, transactions, nil); err != nil {
+		return err
+	}
+	return sm.db.Write(batch, nil)
+}
+
+// BlockBatch gathers blocks, their transactions and a status to store in one write
+type BlockBatch struct {
+	sm     *StorageManager
+	batch  *leveldb.Batch
+	blocks int
+	// Transactions added since the last write, by hash
+	confirmed map[string]bool
+}
+
+// NewBlockBatch creates an empty block batch
+func (sm *StorageManager) NewBlockBatch() *BlockBatch {
+	return &BlockBatch{sm: sm, batch: new(leveldb.Batch), confirmed: make(map[string]bool)}
+}
+
+// PutBlock adds a block with its transactions and their indexes
+func (b *BlockBatch) PutBlock(block *pb.Block, transactions []*pb.Transaction) error {
+	if err := b.sm.putBlock(b.batch, block, transactions, b.confirmed); err != nil {
+		return err
+	}
+	b.blocks++
+	return nil
+}
+
+// PutStatus adds a status, written along with the blocks
+func (b *BlockBatch) PutStatus(key string, status *pb.Status) error {
+	return putMessage(b.batch, StatusPrefix+key, status)
+}
+
+// PutIndexesVersion marks the indexes as current, for chains started empty
+func (b *BlockBatch) PutIndexesVersion() error {
+	return putMessage(b.batch, StatusPrefix+pb.IndexesKey, &pb.IndexesStatus{Version: IndexesVersion})
+}
+
+// Blocks returns the number of blocks added since the last write
+func (b *BlockBatch) Blocks() int {
+	return b.blocks
+}
+
+// Write stores everything added, then empties the batch
+func (b *BlockBatch) Write() error {
+	if err := b.sm.db.Write(b.batch, nil); err != nil {
+		return err
+	}
+	b.batch.Reset()
+	b.blocks = 0
+	clear(b.confirmed)
+	return nil
+}
+
+// Adds a block to the batch, `confirmed` gathering the hashes of the transactions
+// added before in the same batch when not nil
+func (sm *StorageManager) putBlock(batch *leveldb.Batch, block *pb.Block, transactions []*pb.Transaction, confirmed map[string]bool) error {
+	key := sm.BlockKey(block.Height)
+
+	// A block replaced at the same height leaves its old hash and transactions behind
+	previous := &pb.Block{}
+	if err := sm.BlockStorage().Get(key, previous); err == nil && previous.Hash != block.Hash {
+		batch.Delete([]byte(BlockHashPrefix + previous.Hash))
+		if err := sm.deleteBlockTransactions(batch, block.Height, confirmed); err != nil {
+			return err
+		}
+	}
+
+	if err := putMessage(batch, BlockPrefix+key, block); err != nil {
+		return err
+	}
+	if err := putMessage(batch, BlockHashPrefix+block.Hash, &pb.StorageIndex{Key: key}); err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if err := sm.putTransaction(batch, transaction); err != nil {
+			return err
+		}
+		if confirmed != nil {
+			confirmed[transaction.Hash] = true
+		}
+	}
+	return nil
+}
+
+// Deletes the stored transactions of a height and their indexes, but the hash
+// index of those `confirmed` again in a block added before in the same batch
+func (sm *StorageManager) deleteBlockTransactions(batch *leveldb.Batch, height uint64, confirmed map[string]bool) error {
+	transactions, err := sm.GetBlockTransactions(height)
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		batch.Delete([]byte(TransactionPrefix + sm.TransactionKey(height, transaction.Hash)))
+		for _, indexKey := range sm.transactionIndexKeys(transaction) {
+			if confirmed[transaction.Hash] && indexKey == TransactionHashPrefix+transaction.Hash {
+				continue
+			}
+			batch.Delete([]byte(indexKey))
+		}
+	}
+	return nil
+}
+
+// PutTransaction stores a confirmed transaction and indexes it by hash, in one write
+func (sm *StorageManager) PutTransaction(transaction *pb.Transaction) error {
+	batch := new(leveldb.Batch)
+	if err := sm.putTransaction(batch, transaction); err != nil {
+		return err
+	}
+	return sm.db.Write(batch, nil)
+}
+
+func (sm *StorageManager) putTransaction(batch *leveldb.Batch, transaction *pb.Transaction) error {
+	key := sm.TransactionKey(transaction.BlockHeight, transaction.Hash)
+	if err := putMessage(batch, TransactionPrefix+key, transaction); err != nil {
+		return err
+	}
+	for _, indexKey := range sm.transactionIndexKeys(transaction) {
+		if err := putMessage(batch, indexKey, &pb.StorageIndex{Key: key}); err != nil {
+			return err
+		}
+	}
+
+	// Once confirmed it is no longer pending, which is stored at height 0
+	pendingKey := sm.TransactionKey(0, transaction.Hash)
+	batch.Delete([]byte(PendingTransactionPrefix + pendingKey))
+	batch.Delete([]byte(sm.pendingSenderKey(transaction, pendingKey)))
+	return nil
+}
+
+// The hash index key and one address index key per party of the transaction
+func (sm *StorageManager) transactionIndexKeys(transaction *pb.Transaction) []string {
+	keys := []string{TransactionHashPrefix + transaction.Hash}
+	for _, address := range transactionAddresses(transaction) {
+		keys = append(keys, AddressPrefix+sm.AddressKey(address, transaction.BlockHeight, transaction.Hash))
+	}
+	return keys
+}
+
+// The distinct, non empty sender and receiver of a transaction
+func transactionAddresses(transaction *pb.Transaction) []string {
+	var addresses []string
+	if transaction.Sender != "" {
+		addresses = append(addresses, transaction.Sender)
+	}
+	if transaction.Receiver != "" && transaction.Receiver != transaction.Sender {
+		addresses = append(addresses, transaction.Receiver)
+	}
+	return addresses
+}
+
+// GetBlockByHash retrieves a block through the hash index
+func (sm *StorageManager) GetBlockByHash(hash string) (*pb.Block, error) {
+	index := &pb.StorageIndex{}
+	if err := sm.BlockHashStorage().Get(hash, index); err != nil {
+		return nil, err
+	}
+
+	block := &pb.Block{}
+	if err := sm.BlockStorage().Get(index.Key, block); err != nil {
+		return nil, err
+	}
+	if block.Hash != hash {
+		return nil, fmt.Errorf("block '%s' was replaced: %w", hash, ErrNotFound)
+	}
+	return block, nil
+}
+
+// GetTransactionByHash retrieves a confirmed transaction through the hash index
+func (sm *StorageManager) GetTransactionByHash(hash string) (*pb.Transaction, error) {
+	index := &pb.StorageIndex{}
+	if err := sm.TransactionHashStorage().Get(hash, index); err != nil {
+		return nil, err
+	}
+
+	transaction := &pb.Transaction{}
+	if err := sm.TransactionStorage().Get(index.Key, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// GetBlocks returns the blocks from `from` to `to`, both included, in height order
+func (sm *StorageManager) GetBlocks(from uint64, to uint64) ([]*pb.Block, error) {
+	blocks, err := sm.BlockStorage().GetRange(sm.BlockKey(from), sm.BlockKey(to+1), func() *pb.Block {
+		return &pb.Block{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedValues(blocks), nil
+}
+
+// GetBlockTransactions returns the confirmed transactions of a block, in key order
+func (sm *StorageManager) GetBlockTransactions(height uint64) ([]*pb.Transaction, error) {
+	// ';' sorts right after the ':' separating the height from the hash
+	start := fmt.Sprintf("%016d:", height)
+	end := fmt.Sprintf("%016d;", height)
+	transactions, err := sm.TransactionStorage().GetRange(start, end, func() *pb.Transaction {
+		return &pb.Transaction{}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedValues(transactions), nil
+}
+
+// IndexesInSync tells if the indexes were built by the current version,
+// through Reindex or since the chain was empty
+func (sm *StorageManager) IndexesInSync() (bool, error) {
+	indexes := &pb.IndexesStatus{}
+	err := newStorage[*pb.IndexesStatus](sm.db, StatusPrefix).Get(pb.IndexesKey, indexes)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return indexes.Version == IndexesVersion, nil
+}
+
+// Reindex rebuilds the block hash, transaction hash, address and pending sender indexes
+func (sm *StorageManager) Reindex() error {
+	batch := new(leveldb.Batch)
+
+	for _, prefix := range []string{BlockHashPrefix, TransactionHashPrefix, AddressPrefix, PendingSenderPrefix} {
+		iter := sm.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		for iter.Next() {
+			batch.Delete(slices.Clone(iter.Key()))
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return err
+		}
+	}
+
+	iter := sm.db.NewIterator(util.BytesPrefix([]byte(BlockPrefix)), nil)
+	for iter.Next() {
+		key := string(iter.Key()[len(BlockPrefix):])
+		block := &pb.Block{}
+		if err := proto.Unmarshal(iter.Value(), block); err != nil {
+			iter.Release()
+			return fmt.Errorf("failed to unmarshal value for key %s: %w", key, err)
+		}
+		if err := putMessage(batch, BlockHashPrefix+block.Hash, &pb.StorageIndex{Key: key}); err != nil {
+			iter.Release()
+			return err
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	err := sm.forEachTransaction(TransactionPrefix, func(key string, transaction *pb.Transaction) error {
+		for _, indexKey := range sm.transactionIndexKeys(transaction) {
+			if err := putMessage(batch, indexKey, &pb.StorageIndex{Key: key}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = sm.forEachTransaction(PendingTransactionPrefix, func(key string, transaction *pb.Transaction) error {
+		return putMessage(batch, sm.pendingSenderKey(transaction, key), &pb.StorageIndex{Key: key})
+	})
+	if err != nil {
+		return err
+	}
+	if err := putMessage(batch, StatusPrefix+pb.IndexesKey, &pb.IndexesStatus{Version: IndexesVersion}); err != nil {
+		return err
+	}
+
+	return sm.db.Write(batch, nil)
+}
+
+// Calls fn with the key and value of every transaction stored under the prefix,
+// confirmed or pending
+func (sm *StorageManager) forEachTransaction(prefix string, fn func(key string, transaction *pb.Transaction) error) error {
+	iter := sm.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := string(iter.Key()[len(prefix):])
+		transaction := &pb.Transaction{}
+		if err := proto.Unmarshal(iter.Value(), transaction); err != nil {
+			return fmt.Errorf("failed to unmarshal value for key %s: %w", key, err)
+		}
+		if err := fn(key, transaction); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func putMessage(batch *leveldb.Batch, key string, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal protobuf: %w", err)
+	}
+	batch.Put([]byte(key), data)
+	return nil
+}
+
+// Returns the values of a range result ordered by key
+func sortedValues[T any](values map[string]T) []T {
+	result := make([]T, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		result = append(result, values[key])
+	}
+	return result
+}
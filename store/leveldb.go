@@ -19,8 +19,17 @@ const (
 	TransactionPrefix        = "transaction:"
 	PendingTransactionPrefix = "pending:"
 	PeerInfoPrefix           = "peer:"
+	SeedPeerInfoPrefix       = "seed:"
+	NodePeerInfoPrefix       = "node:"
+	BlockHashPrefix          = "block-hash:"
+	TransactionHashPrefix    = "transaction-hash:"
+	AddressPrefix            = "address:"
+	PendingSenderPrefix      = "pending-sender:"
 )
 
+// ErrNotFound is returned, wrapped, when a key is not in storage
+var ErrNotFound = leveldb.ErrNotFound
+
 // ProtoMessage interface for protobuf messages
 type ProtoMessage interface {
 	proto.Message
@@ -233,6 +242,22 @@ func (sm *StorageManager) PeerInfoStorage() *Storage[*pb.PeerInfo] {
 	return newStorage[*pb.PeerInfo](sm.db, PeerInfoPrefix)
 }
 
+func (sm *StorageManager) SeedPeerInfoStorage() *Storage[*pb.PeerInfo] {
+	return newStorage[*pb.PeerInfo](sm.db, SeedPeerInfoPrefix)
+}
+
+func (sm *StorageManager) NodePeerInfoStorage() *Storage[*pb.PeerInfo] {
+	return newStorage[*pb.PeerInfo](sm.db, NodePeerInfoPrefix)
+}
+
+func (sm *StorageManager) BlockHashStorage() *Storage[*pb.StorageIndex] {
+	return newStorage[*pb.StorageIndex](sm.db, BlockHashPrefix)
+}
+
+func (sm *StorageManager) TransactionHashStorage() *Storage[*pb.StorageIndex] {
+	return newStorage[*pb.StorageIndex](sm.db, TransactionHashPrefix)
+}
+
 // Utility functions for key generation
 func (sm *StorageManager) BlockKey(height uint64) string {
 	return fmt.Sprintf("%016d", height) // Zero-padded for proper ordering
@@ -242,6 +267,10 @@ func (sm *StorageManager) TransactionKey(blockHeight uint64, txHash string) stri
 	return fmt.Sprintf("%016d:%s", blockHeight, txHash)
 }
 
+func (sm *StorageManager) AddressKey(address string, blockHeight uint64, txHash string) string {
+	return fmt.Sprintf("%s:%016d:%s", address, blockHeight, txHash)
+}
+
 func (sm *StorageManager) PeerKey(address string, id string) string {
 	return fmt.Sprintf("%s:%s", address, id)
 }
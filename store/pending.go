@@ -0,0 +1,56 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// PutPendingTransaction stores a pending transaction and indexes it by sender, in one write
+func (sm *StorageManager) PutPendingTransaction(transaction *pb.Transaction) error {
+	batch := new(leveldb.Batch)
+	key := sm.TransactionKey(transaction.BlockHeight, transaction.Hash)
+	if err := putMessage(batch, PendingTransactionPrefix+key, transaction); err != nil {
+		return err
+	}
+	if err := putMessage(batch, sm.pendingSenderKey(transaction, key), &pb.StorageIndex{Key: key}); err != nil {
+		return err
+	}
+	return sm.db.Write(batch, nil)
+}
+
+// GetPendingTransactionsBySender returns the pending transactions sent by an address
+func (sm *StorageManager) GetPendingTransactionsBySender(sender string) ([]*pb.Transaction, error) {
+	iter := sm.db.NewIterator(util.BytesPrefix([]byte(PendingSenderPrefix+sender+":")), nil)
+	defer iter.Release()
+
+	pendingStorage := sm.PendingTransactionStorage()
+	var transactions []*pb.Transaction
+	for iter.Next() {
+		index := &pb.StorageIndex{}
+		if err := proto.Unmarshal(iter.Value(), index); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal index: %w", err)
+		}
+		transaction := &pb.Transaction{}
+		err := pendingStorage.Get(index.Key, transaction)
+		// Left behind by a transaction no longer pending
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, iter.Error()
+}
+
+func (sm *StorageManager) pendingSenderKey(transaction *pb.Transaction, key string) string {
+	return PendingSenderPrefix + transaction.Sender + ":" + key
+}
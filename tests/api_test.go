@@ -0,0 +1,446 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+// Backend answering with fixed values, and chain data from its storage
+type fakeBackend struct {
+	blocksErr error
+	// Answers with a chain holding only block zero
+	atZero    bool
+	sm        *store.StorageManager
+	submitted map[string]bool
+	shutdown  bool
+	bans      map[string]*pb.PeerInfo
+}
+
+func (b *fakeBackend) BlocksStatus() (*pb.APIBlocksStatus, error) {
+	if b.blocksErr != nil {
+		return nil, b.blocksErr
+	}
+	if b.atZero {
+		return &pb.APIBlocksStatus{Height: 0, LastHash: "abc"}, nil
+	}
+	return &pb.APIBlocksStatus{Height: 42, LastHash: "abc"}, nil
+}
+
+func (b *fakeBackend) NetworkStatus() *pb.APINetworkStatus {
+	return &pb.APINetworkStatus{
+		Network:        api.NetworkMainnet,
+		Mode:           cfg.NodeModeNode,
+		PeerId:         "QmNode",
+		ConnectedPeers: 3,
+		SeedPeers:      2,
+		NodePeers:      1,
+	}
+}
+
+func (b *fakeBackend) Block(height uint64) (*pb.Block, error) {
+	block := &pb.Block{}
+	if err := b.sm.BlockStorage().Get(b.sm.BlockKey(height), block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (b *fakeBackend) BlockByHash(hash string) (*pb.Block, error) {
+	return b.sm.GetBlockByHash(hash)
+}
+
+func (b *fakeBackend) Blocks(from uint64, to uint64) ([]*pb.Block, error) {
+	return b.sm.GetBlocks(from, to)
+}
+
+func (b *fakeBackend) BlockTransactions(height uint64) ([]*pb.Transaction, error) {
+	return b.sm.GetBlockTransactions(height)
+}
+
+func (b *fakeBackend) Transaction(hash string) (*pb.Transaction, error) {
+	return b.sm.GetTransactionByHash(hash)
+}
+
+func (b *fakeBackend) AddressBalance(address string) (*pb.APIAddressBalance, error) {
+	totals, err := b.sm.GetAddressTotals(address)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.APIAddressBalance{
+		Address:      address,
+		Balance:      int64(totals.Received) - int64(totals.Sent),
+		Received:     totals.Received,
+		Sent:         totals.Sent,
+		Transactions: totals.Transactions,
+	}, nil
+}
+
+func (b *fakeBackend) AddressTransactions(address string, cursor string, limit int) (*pb.APIAddressTransactions, error) {
+	transactions, next, err := b.sm.GetAddressTransactions(address, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.APIAddressTransactions{Address: address, Transactions: transactions, NextCursor: next}, nil
+}
+
+func (b *fakeBackend) SubmitTransaction(transaction *pb.Transaction) (string, error) {
+	if err := transaction.ValidatePending(time.Now().Unix()); err != nil {
+		return "", err
+	}
+	if b.submitted[transaction.Hash] {
+		return "", pb.ErrTransactionKnown
+	}
+	if b.submitted == nil {
+		b.submitted = map[string]bool{}
+	}
+	b.submitted[transaction.Hash] = true
+	return transaction.Hash, nil
+}
+
+func (b *fakeBackend) Peers() []*pb.PeerInfo {
+	return []*pb.PeerInfo{
+		{Id: "QmSeed", Address: "10.0.0.1", Port: 45050, Mode: cfg.NodeModeSeed, Connected: true, Direction: pb.DirectionOutbound, LatencyMs: 20},
+	}
+}
+
+func (b *fakeBackend) RequestShutdown() {
+	b.shutdown = true
+}
+
+func (b *fakeBackend) Rescan() error {
+	return b.blocksErr
+}
+
+func (b *fakeBackend) ConnectPeer(address string) (*pb.PeerInfo, error) {
+	switch address {
+	case "invalid":
+		return nil, api.ErrInvalidPeerAddress
+	case "/ip4/10.0.0.9/tcp/45050/p2p/QmGone":
+		return nil, api.ErrPeerUnreachable
+	}
+	return &pb.PeerInfo{Id: "QmNew", Address: "10.0.0.2", Connected: true, Direction: pb.DirectionOutbound}, nil
+}
+
+func (b *fakeBackend) DisconnectPeer(id string) error {
+	if id != "QmSeed" {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (b *fakeBackend) BanPeer(id string) error {
+	if id == "invalid" {
+		return api.ErrInvalidPeerID
+	}
+	if b.bans == nil {
+		b.bans = map[string]*pb.PeerInfo{}
+	}
+	b.bans[id] = &pb.PeerInfo{Id: id}
+	return nil
+}
+
+func (b *fakeBackend) UnbanPeer(id string) error {
+	if _, ok := b.bans[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(b.bans, id)
+	return nil
+}
+
+func (b *fakeBackend) BannedPeers() ([]*pb.PeerInfo, error) {
+	peers := []*pb.PeerInfo{}
+	for _, info := range b.bans {
+		peers = append(peers, info)
+	}
+	return peers, nil
+}
+
+// Creates a backend with a short chain of blocks, each with one transaction
+func newChainBackend(t *testing.T, blocks int) *fakeBackend {
+	sm := newTempStorage(t)
+	for height := range uint64(blocks) {
+		block := &pb.Block{Height: height, Hash: fmt.Sprintf("block%d", height)}
+		transaction := &pb.Transaction{Hash: fmt.Sprintf("tx%d", height), BlockHeight: height}
+		assert.NilError(t, sm.PutBlock(block, []*pb.Transaction{transaction}))
+	}
+	return &fakeBackend{sm: sm}
+}
+
+// Creates an API server on top of the backend
+func newTestAPI(t *testing.T, backend api.Backend) *api.Server {
+	var wg sync.WaitGroup
+
+	server, err := api.NewServer(context.Background(), func() {}, &wg, backend, "127.0.0.1:0", cfg.DefaultAPIConfig())
+	assert.NilError(t, err)
+	return server
+}
+
+// Test that the API server asks the node to stop when it can't listen
+func TestAPIStartFailure(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	var wg sync.WaitGroup
+	shutdowns := 0
+	server, err := api.NewServer(context.Background(), func() { shutdowns++ }, &wg, &fakeBackend{}, listener.Addr().String(), cfg.DefaultAPIConfig())
+	assert.NilError(t, err)
+
+	wg.Add(1)
+	server.Start()
+	assert.Equal(t, 1, shutdowns)
+}
+
+// Performs a GET request against the API server
+func apiGet(server *api.Server, endpoint string, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, api.Route(endpoint), nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// Test that the JSON answers decode into the messages
+func TestAPIStatusJSON(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, &fakeBackend{})
+
+	rec := apiGet(server, api.APIBlocksStatus, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	blocks := &pb.APIBlocksStatus{}
+	assert.NilError(t, pb.UnmarshalJSON(rec.Body.Bytes(), blocks))
+	assert.Equal(t, uint64(42), blocks.Height)
+	assert.Equal(t, "abc", blocks.LastHash)
+
+	rec = apiGet(server, api.APINetworkStatus, pb.ContentTypeJSON)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	network := &pb.APINetworkStatus{}
+	assert.NilError(t, pb.UnmarshalJSON(rec.Body.Bytes(), network))
+	assert.Equal(t, "QmNode", network.PeerId)
+	assert.Equal(t, uint32(3), network.ConnectedPeers)
+	assert.Equal(t, uint32(2), network.SeedPeers)
+}
+
+// Test that zero values are kept in the JSON answers
+func TestAPIStatusJSONZero(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, &fakeBackend{atZero: true})
+
+	rec := apiGet(server, api.APIBlocksStatus, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	fields := map[string]any{}
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &fields))
+	assert.Equal(t, "0", fields["height"])
+
+	rec = apiGet(server, api.APINetworkStatus, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	fields = map[string]any{}
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &fields))
+	assert.Equal(t, float64(0), fields["dns_peers"])
+}
+
+// Test that protobuf is served when asked for
+func TestAPIStatusProtoBuf(t *testing.T) {
+	t.Parallel()
+
+	rec := apiGet(newTestAPI(t, &fakeBackend{}), api.APIBlocksStatus, pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	msg := &pb.APIBlocksStatus{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), msg))
+	assert.Equal(t, uint64(42), msg.Height)
+}
+
+// Test that backend failures and wrong methods are reported
+func TestAPIStatusErrors(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, &fakeBackend{blocksErr: errors.New("broken")})
+	rec := apiGet(server, api.APIBlocksStatus, "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, api.Route(api.APINetworkStatus), nil)
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// Test the block explorer endpoints
+func TestAPIExplorer(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, newChainBackend(t, 5))
+
+	rec := apiGet(server, "blocks/3", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	block := &pb.Block{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), block))
+	assert.Equal(t, "block3", block.Hash)
+
+	rec = apiGet(server, "blocks/hash/block2", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), block))
+	assert.Equal(t, uint64(2), block.Height)
+
+	rec = apiGet(server, "blocks?from=1&to=3", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	blocks := &pb.APIBlocks{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), blocks))
+	assert.Equal(t, 3, len(blocks.Blocks))
+	assert.Equal(t, uint64(1), blocks.Blocks[0].Height)
+
+	rec = apiGet(server, "transactions?block=4", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	transactions := &pb.APITransactions{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), transactions))
+	assert.Equal(t, "tx4", transactions.Transactions[0].Hash)
+
+	rec = apiGet(server, "blocks/3/transactions", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), transactions))
+	assert.Equal(t, "tx3", transactions.Transactions[0].Hash)
+
+	rec = apiGet(server, "transactions/tx1", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	transaction := &pb.Transaction{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), transaction))
+	assert.Equal(t, uint64(1), transaction.BlockHeight)
+}
+
+// Test that the explorer tells missing data from bad requests
+func TestAPIExplorerErrors(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, newChainBackend(t, 2))
+
+	assert.Equal(t, http.StatusNotFound, apiGet(server, "blocks/7", "").Code)
+	assert.Equal(t, http.StatusNotFound, apiGet(server, "blocks/hash/nope", "").Code)
+	assert.Equal(t, http.StatusNotFound, apiGet(server, "transactions/nope", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "blocks/abc", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "blocks/abc/transactions", "").Code)
+	assert.Equal(t, http.StatusNotFound, apiGet(server, "blocks/1/nope", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "blocks?from=5&to=1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "blocks?from=0&to=500", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "transactions", "").Code)
+}
+
+// Test the balance and paged history of an address
+func TestAPIAddresses(t *testing.T) {
+	t.Parallel()
+
+	_, _, alice := legacy.GenerateNewAddress()
+	_, _, bob := legacy.GenerateNewAddress()
+
+	backend := &fakeBackend{sm: newTempStorage(t)}
+	for height := range uint64(5) {
+		transaction := &pb.Transaction{
+			Hash:        fmt.Sprintf("tx%d", height),
+			BlockHeight: height,
+			Sender:      "COINBASE",
+			Receiver:    alice,
+			Amount:      100,
+		}
+		if height == 4 {
+			transaction.Sender = alice
+			transaction.Receiver = bob
+			transaction.Amount = 150
+		}
+		block := &pb.Block{Height: height, Hash: fmt.Sprintf("block%d", height)}
+		assert.NilError(t, backend.sm.PutBlock(block, []*pb.Transaction{transaction}))
+	}
+	server := newTestAPI(t, backend)
+
+	rec := apiGet(server, "addresses/"+alice+"/balance", pb.ContentTypeProtoBuf)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	balance := &pb.APIAddressBalance{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), balance))
+	assert.Equal(t, int64(250), balance.Balance)
+	assert.Equal(t, uint64(400), balance.Received)
+	assert.Equal(t, uint32(5), balance.Transactions)
+
+	// Walk the history two at a time, newest first
+	var hashes []string
+	cursor := ""
+	for range 5 {
+		rec = apiGet(server, "addresses/"+alice+"/transactions?limit=2&cursor="+cursor, pb.ContentTypeProtoBuf)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		page := &pb.APIAddressTransactions{}
+		assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), page))
+		for _, transaction := range page.Transactions {
+			hashes = append(hashes, transaction.Hash)
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	assert.DeepEqual(t, []string{"tx4", "tx3", "tx2", "tx1", "tx0"}, hashes)
+
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "addresses/nope/balance", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "addresses/"+alice+"/transactions?cursor=nope", "").Code)
+	assert.Equal(t, http.StatusBadRequest, apiGet(server, "addresses/"+alice+"/transactions?limit=0", "").Code)
+}
+
+// Test submitting signed transactions and the errors on bad ones
+func TestAPISubmitTransaction(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, &fakeBackend{})
+	transaction := newSignedTransaction(t)
+	body, err := proto.Marshal(transaction)
+	assert.NilError(t, err)
+
+	rec := apiPost(server, api.APITransactions, pb.ContentTypeProtoBuf, body)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	submitted := &pb.APITransactionSubmitted{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), submitted))
+	assert.Equal(t, transaction.Hash, submitted.Hash)
+
+	rec = apiPost(server, api.APITransactions, pb.ContentTypeProtoBuf, body)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	transaction.Amount++
+	body, err = proto.Marshal(transaction)
+	assert.NilError(t, err)
+	rec = apiPost(server, api.APITransactions, pb.ContentTypeProtoBuf, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	apiErr := &pb.APIError{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), apiErr))
+	assert.Equal(t, "verify", apiErr.Field)
+
+	rec = apiPost(server, api.APITransactions, "application/xml", []byte("<nope/>"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+// Performs a POST request against the API server
+func apiPost(server *api.Server, endpoint string, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, api.Route(endpoint), bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
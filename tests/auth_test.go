@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+const (
+	cTestReadToken  = "reader"
+	cTestAdminToken = "administrator"
+)
+
+// Creates an API server with a read and an admin token
+func newTestAuthAPI(t *testing.T, backend api.Backend, publicReads bool) *api.Server {
+	var wg sync.WaitGroup
+
+	config := cfg.DefaultAPIConfig()
+	config.Tokens = []string{"read:" + cTestReadToken, "admin:" + cTestAdminToken}
+	config.PublicReads = publicReads
+	server, err := api.NewServer(context.Background(), func() {}, &wg, backend, "127.0.0.1:0", config)
+	assert.NilError(t, err)
+	return server
+}
+
+// Performs a request with a bearer token, none when empty
+func apiAuthRequest(server *api.Server, method string, endpoint string, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, api.Route(endpoint), nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// Test the roles allowed on reads and admin operations
+func TestAPIAuthRoles(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeBackend{}
+	server := newTestAuthAPI(t, backend, true)
+
+	tests := []struct {
+		name     string
+		method   string
+		endpoint string
+		token    string
+		status   int
+	}{
+		{"public read", http.MethodGet, api.APIBlocksStatus, "", http.StatusOK},
+		{"admin without token", http.MethodPost, api.APIShutdown, "", http.StatusUnauthorized},
+		{"admin with unknown token", http.MethodPost, api.APIShutdown, "unknown", http.StatusUnauthorized},
+		{"admin with read token", http.MethodPost, api.APIShutdown, cTestReadToken, http.StatusForbidden},
+		{"bans with read token", http.MethodGet, api.APIBans, cTestReadToken, http.StatusForbidden},
+		{"bans with admin token", http.MethodGet, api.APIBans, cTestAdminToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		rec := apiAuthRequest(server, tt.method, tt.endpoint, tt.token)
+		assert.Equal(t, tt.status, rec.Code, tt.name)
+		if tt.status == http.StatusUnauthorized {
+			assert.Assert(t, rec.Header().Get("WWW-Authenticate") != "", tt.name)
+		}
+	}
+	assert.Assert(t, !backend.shutdown)
+
+	rec := apiAuthRequest(server, http.MethodPost, api.APIShutdown, cTestAdminToken)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Assert(t, backend.shutdown)
+}
+
+// Test that reads need a token once they aren't public
+func TestAPIAuthPrivateReads(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAuthAPI(t, &fakeBackend{}, false)
+
+	assert.Equal(t, http.StatusUnauthorized, apiAuthRequest(server, http.MethodGet, api.APIBlocksStatus, "").Code)
+	assert.Equal(t, http.StatusOK, apiAuthRequest(server, http.MethodGet, api.APIBlocksStatus, cTestReadToken).Code)
+	assert.Equal(t, http.StatusOK, apiAuthRequest(server, http.MethodGet, api.APIBlocksStatus, cTestAdminToken).Code)
+
+	// Basic auth carries the token as password
+	req := httptest.NewRequest(http.MethodGet, api.Route(api.APIBlocksStatus), nil)
+	req.SetBasicAuth("anyone", cTestReadToken)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// Test banning and lifting bans through the admin endpoints
+func TestAPIAuthBans(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAuthAPI(t, &fakeBackend{}, true)
+	ban := func(method string, id string) int {
+		return apiAuthRequest(server, method, "network/bans/"+id, cTestAdminToken).Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, ban(http.MethodPut, "QmPeer"))
+	assert.Equal(t, http.StatusBadRequest, ban(http.MethodPut, "invalid"))
+	assert.Equal(t, http.StatusNoContent, ban(http.MethodDelete, "QmPeer"))
+	assert.Equal(t, http.StatusNotFound, ban(http.MethodDelete, "QmPeer"))
+	assert.Equal(t, http.StatusMethodNotAllowed, ban(http.MethodGet, "QmPeer"))
+}
+
+// Test the cookie grants the admin role while it exists
+func TestAPIAuthCookie(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAuthAPI(t, &fakeBackend{}, true)
+	cookieFile := filepath.Join(t.TempDir(), ".cookie")
+	assert.NilError(t, server.WriteCookie(cookieFile))
+
+	info, err := os.Stat(cookieFile)
+	assert.NilError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	token, err := api.ReadCookie(cookieFile)
+	assert.NilError(t, err)
+	assert.Equal(t, http.StatusOK, apiAuthRequest(server, http.MethodGet, api.APIBans, token).Code)
+
+	assert.NilError(t, os.WriteFile(cookieFile, []byte("someone:"+token), 0o600))
+	_, err = api.ReadCookie(cookieFile)
+	assert.ErrorContains(t, err, "invalid cookie file")
+}
+
+// Test the gRPC service asks for a token once reads aren't public
+func TestGRPCAuth(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAuthAPI(t, &fakeBackend{}, false)
+	grpcServer, err := api.NewGRPCServer(server, "", cfg.DefaultAPIConfig())
+	assert.NilError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.ShutDown)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NilError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := pb.NewAPIClient(conn)
+
+	_, err = client.GetBlocksStatus(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+cTestReadToken)
+	_, err = client.GetBlocksStatus(ctx, &emptypb.Empty{})
+	assert.NilError(t, err)
+}
+
+// Test that invalid token entries are refused without showing the secret
+func TestAPIAuthInvalidTokens(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+	for _, entry := range []string{"s3cr3tt0k3n", "root:s3cr3tt0k3n"} {
+		config := cfg.DefaultAPIConfig()
+		config.Tokens = []string{"read:" + cTestReadToken, entry}
+		_, err := api.NewServer(context.Background(), func() {}, &wg, &fakeBackend{}, "127.0.0.1:0", config)
+		assert.ErrorContains(t, err, "api token 2")
+		assert.Assert(t, !strings.Contains(err.Error(), "s3cr3tt0k3n"), entry)
+	}
+}
@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Serves the API over a loopback listener and returns a client for it
+func newTestClient(t *testing.T, server *api.Server, token string) *api.Client {
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	client, err := api.NewClient(httpServer.URL, token, nil)
+	assert.NilError(t, err)
+	return client
+}
+
+// Test the client decodes the status answers
+func TestClientStatus(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, newTestAPI(t, &fakeBackend{}), "")
+	ctx := context.Background()
+
+	blocks, err := client.BlocksStatus(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(42), blocks.Height)
+	assert.Equal(t, "abc", blocks.LastHash)
+
+	network, err := client.NetworkStatus(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, "QmNode", network.PeerId)
+	assert.Equal(t, uint32(2), network.SeedPeers)
+}
+
+// Test the errors of the node are told apart from it being down
+func TestClientErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := api.NewClient("ftp://localhost", "", nil)
+	assert.ErrorContains(t, err, "invalid api url")
+
+	client := newTestClient(t, newTestAPI(t, &fakeBackend{blocksErr: errors.New("boom")}), "")
+	_, err = client.BlocksStatus(context.Background())
+	var statusErr *api.StatusError
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+	assert.Equal(t, "Could not get blocks status", statusErr.Message)
+
+	// Nothing listens on a port just released
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	client, err = api.NewClient("http://"+address, "", nil)
+	assert.NilError(t, err)
+	_, err = client.NetworkStatus(context.Background())
+	assert.Assert(t, errors.Is(err, api.ErrNodeUnreachable))
+}
+
+// Test the client sends its token
+func TestClientToken(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAuthAPI(t, &fakeBackend{}, false)
+
+	_, err := newTestClient(t, server, "").BlocksStatus(context.Background())
+	var statusErr *api.StatusError
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+
+	_, err = newTestClient(t, server, cTestReadToken).BlocksStatus(context.Background())
+	assert.NilError(t, err)
+}
+
+// Test the client fetches blocks by height, hash and range
+func TestClientBlocks(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, newTestAPI(t, newChainBackend(t, 5)), "")
+	ctx := context.Background()
+
+	block, err := client.Block(ctx, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, "block2", block.Hash)
+
+	block, err = client.BlockByHash(ctx, "block3")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(3), block.Height)
+
+	blocks, err := client.Blocks(ctx, 1, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(blocks))
+	assert.Equal(t, "block1", blocks[0].Hash)
+
+	_, err = client.Block(ctx, 99)
+	var statusErr *api.StatusError
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+}
+
+// Test the client follows the events stream, replayed blocks first
+func TestClientEvents(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, newChainBackend(t, 3))
+	client := newTestClient(t, server, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errDone := errors.New("done")
+	var heights []uint64
+	from := uint64(1)
+	err := client.Events(ctx, &from, func(event *pb.APIEvent) error {
+		switch payload := event.Payload.(type) {
+		case *pb.APIEvent_Block:
+			heights = append(heights, payload.Block.Block.Height)
+			// Replay done, the next one comes live
+			if payload.Block.Block.Height == 2 {
+				server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Reorg{
+					Reorg: &pb.APIEventReorg{Height: 3, OldHash: "old", NewHash: "new"},
+				}})
+			}
+		case *pb.APIEvent_Reorg:
+			assert.Equal(t, "new", payload.Reorg.NewHash)
+			return errDone
+		}
+		return nil
+	})
+	assert.Assert(t, errors.Is(err, errDone))
+	assert.DeepEqual(t, []uint64{1, 2}, heights)
+}
+
+// Test the client manages the peers of the node with an admin token
+func TestClientPeers(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAuthAPI(t, &fakeBackend{}, true)
+	client := newTestClient(t, server, cTestAdminToken)
+	ctx := context.Background()
+
+	peers, err := client.Peers(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(peers))
+	assert.Equal(t, "QmSeed", peers[0].Id)
+
+	info, err := client.ConnectPeer(ctx, "/ip4/10.0.0.2/tcp/45050/p2p/QmNew")
+	assert.NilError(t, err)
+	assert.Equal(t, "QmNew", info.Id)
+
+	var statusErr *api.StatusError
+	_, err = client.ConnectPeer(ctx, "invalid")
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.Equal(t, api.ErrInvalidPeerAddress.Error(), statusErr.Message)
+
+	_, err = client.ConnectPeer(ctx, "/ip4/10.0.0.9/tcp/45050/p2p/QmGone")
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+
+	assert.NilError(t, client.DisconnectPeer(ctx, "QmSeed"))
+	err = client.DisconnectPeer(ctx, "QmOther")
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+
+	assert.NilError(t, client.BanPeer(ctx, "QmBad"))
+	banned, err := client.BannedPeers(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(banned))
+	assert.NilError(t, client.UnbanPeer(ctx, "QmBad"))
+
+	// Managing peers is for admins only
+	_, err = newTestClient(t, server, cTestReadToken).Peers(ctx)
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+}
+
+// Test the client reads balances and submits signed transactions
+func TestClientTransactions(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, newTestAPI(t, newChainBackend(t, 1)), "")
+	ctx := context.Background()
+
+	balance, err := client.AddressBalance(ctx, addressN)
+	assert.NilError(t, err)
+	assert.Equal(t, int64(0), balance.Balance)
+
+	transaction := newSignedTransaction(t)
+	hash, err := client.SubmitTransaction(ctx, transaction)
+	assert.NilError(t, err)
+	assert.Equal(t, transaction.Hash, hash)
+
+	_, err = client.SubmitTransaction(ctx, transaction)
+	var statusErr *api.StatusError
+	assert.Assert(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusConflict, statusErr.StatusCode)
+	assert.Equal(t, pb.ErrTransactionKnown.Error(), statusErr.Message)
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/dns"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Creates a DNS server with a seed and a node
+func newTestDNS(t *testing.T) *dns.DNS {
+	return newTestDNSWithConfig(t, cfg.DefaultDNSConfig())
+}
+
+// Creates a DNS server with a seed, a node and the given config
+func newTestDNSWithConfig(t *testing.T, config *cfg.DNSConfig) *dns.DNS {
+	seeds := pb.NewPeerList()
+	seeds.Add(&pb.PeerInfo{
+		Address: "10.0.0.1",
+		Port:    45050,
+		Id:      "QmSeed",
+		Mode:    "seed",
+	})
+	nodes := pb.NewPeerList()
+	nodes.Add(&pb.PeerInfo{
+		Address: "10.0.0.2",
+		Port:    45050,
+		Id:      "QmNode",
+		Mode:    "node",
+	})
+	return newTestDNSWithPeers(t, config, seeds, nodes, nil)
+}
+
+// Creates a DNS server with the given peers, config and banned peers
+func newTestDNSWithPeers(t *testing.T, config *cfg.DNSConfig, seeds *pb.PeerList, nodes *pb.PeerList, banned dns.BannedFunc) *dns.DNS {
+	var wg sync.WaitGroup
+
+	server, err := dns.NewDNS(
+		context.Background(),
+		func() {},
+		&wg,
+		&pb.PeerInfo{Address: "10.0.0.42", Port: 45050, Id: "QmDNS", Mode: "dns"},
+		seeds,
+		nodes,
+		nil,
+		banned,
+		"127.0.0.1:0",
+		0,
+		config,
+	)
+	assert.NilError(t, err)
+	return server
+}
+
+// Performs a GET request against the DNS server
+func dnsGet(t *testing.T, server *dns.DNS, path string, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// Test that JSON is served by default
+func TestDNSNegotiateDefaultJSON(t *testing.T) {
+	t.Parallel()
+
+	rec := dnsGet(t, newTestDNS(t), "/v1/seeds", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pb.ContentTypeJSON, rec.Header().Get("Content-Type"))
+
+	msg := &pb.DNSPeersResponse{}
+	assert.NilError(t, pb.UnmarshalJSON(rec.Body.Bytes(), msg))
+	assert.Equal(t, 1, len(msg.Peers))
+	assert.Equal(t, "QmSeed", msg.Peers[0].Id)
+}
+
+// Test that ProtoBuf is served when preferred
+func TestDNSNegotiateProtoBuf(t *testing.T) {
+	t.Parallel()
+
+	rec := dnsGet(t, newTestDNS(t), "/v1/nodes", "application/json;q=0.5, application/x-protobuf")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Assert(t, strings.HasPrefix(rec.Header().Get("Content-Type"), pb.ContentTypeProtoBuf))
+
+	msg := &pb.DNSPeersResponse{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), msg))
+	assert.Equal(t, 1, len(msg.Peers))
+	assert.Equal(t, "QmNode", msg.Peers[0].Id)
+}
+
+// Test that unknown content types are refused
+func TestDNSNegotiateNotAcceptable(t *testing.T) {
+	t.Parallel()
+
+	rec := dnsGet(t, newTestDNS(t), "/v1/seeds", "image/png")
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+}
+
+// Test that clients over their rate get a 429
+func TestDNSRateLimit(t *testing.T) {
+	t.Parallel()
+
+	config := cfg.DefaultDNSConfig()
+	config.RateLimit = 0.5
+	config.RateBurst = 2
+	server := newTestDNSWithConfig(t, config)
+
+	assert.Equal(t, http.StatusOK, dnsGet(t, server, "/v1/seeds", "").Code)
+	assert.Equal(t, http.StatusOK, dnsGet(t, server, "/v1/seeds", "").Code)
+
+	rec := dnsGet(t, server, "/v1/seeds", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/dns"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Starts a server answering with a fixed seed list
+func newSeedsServer(t *testing.T, peers ...*pb.PeerInfo) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pb.WriteProtoBuf(w, &pb.DNSPeersResponse{Peers: peers, Total: uint32(len(peers))})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Returns the URL of a server that is no longer listening
+func deadServerURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+// Test that answers are merged by peer ID, keeping the freshest entry
+func TestDNSClientMerge(t *testing.T) {
+	t.Parallel()
+
+	first := newSeedsServer(t,
+		&pb.PeerInfo{Id: "QmA", Address: "10.0.0.1", LastSeen: 10},
+		&pb.PeerInfo{Id: "QmB", Address: "10.0.0.2", LastSeen: 10},
+	)
+	second := newSeedsServer(t,
+		&pb.PeerInfo{Id: "QmB", Address: "10.0.0.20", LastSeen: 20},
+		&pb.PeerInfo{Id: "QmC", Address: "10.0.0.3", LastSeen: 10},
+	)
+
+	client, err := dns.NewClient([]string{first.URL, second.URL}, "")
+	assert.NilError(t, err)
+
+	msg, err := client.Seeds(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, uint32(3), msg.Total)
+	assert.Equal(t, "QmA", msg.Peers[0].Id)
+	assert.Equal(t, "10.0.0.20", msg.Peers[1].Address)
+	assert.Equal(t, "QmC", msg.Peers[2].Id)
+}
+
+// Test that a dead endpoint doesn't stop the others and is marked unhealthy
+func TestDNSClientFailover(t *testing.T) {
+	t.Parallel()
+
+	alive := httptest.NewServer(newTestDNS(t).Handler())
+	t.Cleanup(alive.Close)
+	dead := deadServerURL()
+
+	client, err := dns.NewClient([]string{dead, alive.URL}, "")
+	assert.NilError(t, err)
+
+	msg, err := client.Seeds(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(msg.Peers))
+	assert.Equal(t, "QmSeed", msg.Peers[0].Id)
+
+	health := client.Health()
+	assert.Equal(t, 1, health[0].Failures)
+	assert.Assert(t, !health[0].Healthy())
+	assert.Equal(t, 0, health[1].Failures)
+	assert.Assert(t, health[1].Healthy())
+}
+
+// Test that the last good answer is used when all endpoints are down
+func TestDNSClientCache(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+	server := newSeedsServer(t, &pb.PeerInfo{Id: "QmCached", Address: "10.0.0.1"})
+
+	client, err := dns.NewClient([]string{server.URL}, cacheDir)
+	assert.NilError(t, err)
+	_, err = client.Seeds(context.Background())
+	assert.NilError(t, err)
+
+	offline, err := dns.NewClient([]string{deadServerURL()}, cacheDir)
+	assert.NilError(t, err)
+	msg, err := offline.Seeds(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, "QmCached", msg.Peers[0].Id)
+
+	// Nothing was cached for the nodes
+	_, err = offline.Nodes(context.Background())
+	assert.Assert(t, errors.Is(err, dns.ErrAllEndpointsDown))
+}
+
+// Test that bad endpoint lists are refused
+func TestDNSClientInvalidEndpoints(t *testing.T) {
+	t.Parallel()
+
+	_, err := dns.NewClient(nil, "")
+	assert.Assert(t, errors.Is(err, dns.ErrNoEndpoints))
+
+	_, err = dns.NewClient([]string{"ftp://example.com"}, "")
+	assert.ErrorContains(t, err, "invalid dns endpoint")
+}
+
+// Test that a registration reaches the live endpoints, signed
+func TestDNSClientRegister(t *testing.T) {
+	t.Parallel()
+
+	server := newTestDNS(t)
+	alive := httptest.NewServer(server.Handler())
+	t.Cleanup(alive.Close)
+
+	client, err := dns.NewClient([]string{deadServerURL(), alive.URL}, "")
+	assert.NilError(t, err)
+
+	privateKey, peerInfo := newSignedPeer(t)
+	req, err := dns.NewRegisterRequest(privateKey, peerInfo)
+	assert.NilError(t, err)
+	assert.NilError(t, client.Register(context.Background(), req))
+
+	seeds, err := client.Seeds(context.Background())
+	assert.NilError(t, err)
+	found := false
+	for _, peer := range seeds.Peers {
+		found = found || peer.Id == peerInfo.Id
+	}
+	assert.Assert(t, found)
+
+	// Tampered registrations are refused everywhere
+	req.Peer.Port++
+	err = client.Register(context.Background(), req)
+	assert.Assert(t, errors.Is(err, dns.ErrAllEndpointsDown))
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+type streamEvent struct {
+	name string
+	id   string
+	data string
+}
+
+// Reads the next Server-Sent Event, skipping comments
+func nextStreamEvent(t *testing.T, scanner *bufio.Scanner) streamEvent {
+	var event streamEvent
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "" && event.name != "":
+			return event
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "id: "):
+			event.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	t.Fatalf("stream ended: %v", scanner.Err())
+	return event
+}
+
+// Test resuming a stream, then getting live and filtered events
+func TestAPIEventsStream(t *testing.T) {
+	t.Parallel()
+
+	server := newTestAPI(t, newChainBackend(t, 3))
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+api.Route(api.APIEvents)+"?address="+addressN, nil)
+	assert.NilError(t, err)
+	req.Header.Set("Last-Event-ID", "0")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	scanner := bufio.NewScanner(resp.Body)
+
+	// The blocks after the last one seen come first
+	for _, id := range []string{"1", "2"} {
+		event := nextStreamEvent(t, scanner)
+		assert.Equal(t, "block", event.name)
+		assert.Equal(t, id, event.id)
+	}
+
+	// Another address' transaction and an already sent block are skipped
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Transaction{
+		Transaction: &pb.Transaction{Hash: "other", Sender: "NOther", Receiver: "NSomeone"},
+	}})
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Block{
+		Block: &pb.APIEventBlock{Block: &pb.Block{Height: 2, Hash: "block2"}},
+	}})
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Transaction{
+		Transaction: &pb.Transaction{Hash: "mine", Sender: "NOther", Receiver: addressN},
+	}})
+	event := nextStreamEvent(t, scanner)
+	assert.Equal(t, "transaction", event.name)
+	assert.Assert(t, strings.Contains(event.data, `"hash":"mine"`), event.data)
+
+	// A reorg lets the replacing block through
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Reorg{
+		Reorg: &pb.APIEventReorg{Height: 2, OldHash: "block2", NewHash: "block2b"},
+	}})
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Block{
+		Block: &pb.APIEventBlock{Block: &pb.Block{Height: 2, Hash: "block2b"}},
+	}})
+	event = nextStreamEvent(t, scanner)
+	assert.Equal(t, "reorg", event.name)
+	event = nextStreamEvent(t, scanner)
+	assert.Equal(t, "block", event.name)
+	assert.Equal(t, "2", event.id)
+	assert.Assert(t, strings.Contains(event.data, `"hash":"block2b"`), event.data)
+
+	// Shutting down ends the stream
+	server.ShutDown()
+	for scanner.Scan() {
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Serves the backend over gRPC on a local port, returning the API server and a client
+func newTestGRPC(t *testing.T, backend api.Backend) (*api.Server, pb.APIClient) {
+	server := newTestAPI(t, backend)
+	grpcServer, err := api.NewGRPCServer(server, "", cfg.DefaultAPIConfig())
+	assert.NilError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.ShutDown)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NilError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return server, pb.NewAPIClient(conn)
+}
+
+// Test the queries answer like the REST endpoints
+func TestGRPCQueries(t *testing.T) {
+	t.Parallel()
+
+	_, client := newTestGRPC(t, newChainBackend(t, 3))
+	ctx := context.Background()
+
+	blocksStatus, err := client.GetBlocksStatus(ctx, &emptypb.Empty{})
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(42), blocksStatus.Height)
+
+	block, err := client.GetBlockByHash(ctx, &pb.APIHashRequest{Hash: "block1"})
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), block.Height)
+
+	blocks, err := client.GetBlocks(ctx, &pb.APIBlocksRequest{From: 1})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(blocks.Blocks))
+
+	transactions, err := client.GetBlockTransactions(ctx, &pb.APIHeightRequest{Height: 2})
+	assert.NilError(t, err)
+	assert.Equal(t, "tx2", transactions.Transactions[0].Hash)
+
+	_, err = client.GetTransaction(ctx, &pb.APIHashRequest{Hash: "nope"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetBlocks(ctx, &pb.APIBlocksRequest{From: 10, To: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetAddressBalance(ctx, &pb.APIAddressRequest{Address: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// Test submitting transactions maps the errors to status codes
+func TestGRPCSubmitTransaction(t *testing.T) {
+	t.Parallel()
+
+	_, client := newTestGRPC(t, &fakeBackend{})
+	ctx := context.Background()
+	transaction := newSignedTransaction(t)
+
+	submitted, err := client.SubmitTransaction(ctx, transaction)
+	assert.NilError(t, err)
+	assert.Equal(t, transaction.Hash, submitted.Hash)
+
+	_, err = client.SubmitTransaction(ctx, transaction)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	tampered := proto.Clone(transaction).(*pb.Transaction)
+	tampered.Amount++
+	_, err = client.SubmitTransaction(ctx, tampered)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// Test the events stream replays the missed blocks before the live events
+func TestGRPCSubscribeEvents(t *testing.T) {
+	t.Parallel()
+
+	server, client := newTestGRPC(t, newChainBackend(t, 3))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.SubscribeEvents(ctx, &pb.APIEventsRequest{From: proto.Uint64(1), Address: addressN})
+	assert.NilError(t, err)
+	for _, height := range []uint64{1, 2} {
+		event, err := stream.Recv()
+		assert.NilError(t, err)
+		assert.Equal(t, height, event.GetBlock().Block.Height)
+	}
+
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Transaction{
+		Transaction: &pb.Transaction{Hash: "other", Sender: "NOther", Receiver: "NSomeone"},
+	}})
+	server.Publish(&pb.APIEvent{Payload: &pb.APIEvent_Transaction{
+		Transaction: &pb.Transaction{Hash: "mine", Sender: addressN, Receiver: "NSomeone"},
+	}})
+	event, err := stream.Recv()
+	assert.NilError(t, err)
+	assert.Equal(t, "mine", event.GetTransaction().Hash)
+}
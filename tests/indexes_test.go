@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+// Opens a storage manager on a temporary directory
+func newTempStorage(t *testing.T) *store.StorageManager {
+	sm, err := store.NewStorageManager(t.TempDir())
+	assert.NilError(t, err)
+	t.Cleanup(func() { sm.Close() })
+	return sm
+}
+
+// Test that blocks and transactions can be found by hash
+func TestHashIndexes(t *testing.T) {
+	t.Parallel()
+
+	sm := newTempStorage(t)
+	block := &pb.Block{Height: 1, Hash: "Block1Hash"}
+	transaction := &pb.Transaction{Hash: "Tx1Hash", BlockHeight: 1}
+	assert.NilError(t, sm.PutBlock(block, []*pb.Transaction{transaction}))
+
+	found, err := sm.GetBlockByHash("Block1Hash")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), found.Height)
+
+	foundTransaction, err := sm.GetTransactionByHash("Tx1Hash")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), foundTransaction.BlockHeight)
+
+	// Replacing the block drops the old hash
+	assert.NilError(t, sm.PutBlock(&pb.Block{Height: 1, Hash: "OtherHash"}, nil))
+	_, err = sm.GetBlockByHash("Block1Hash")
+	assert.Assert(t, errors.Is(err, store.ErrNotFound))
+	_, err = sm.GetBlockByHash("OtherHash")
+	assert.NilError(t, err)
+}
+
+// Test that replacing a block drops the transactions of the old one
+func TestReplaceBlock(t *testing.T) {
+	t.Parallel()
+
+	sm := newTempStorage(t)
+	old := []*pb.Transaction{
+		{Hash: "Tx1Hash", BlockHeight: 2, Sender: "NSender", Receiver: "NReceiver", Amount: 1},
+		{Hash: "Tx2Hash", BlockHeight: 2, Sender: "NSender", Receiver: "NReceiver", Amount: 2},
+	}
+	assert.NilError(t, sm.PutBlock(&pb.Block{Height: 1, Hash: "Block1Hash"}, nil))
+	assert.NilError(t, sm.PutBlock(&pb.Block{Height: 2, Hash: "Block2Hash"}, old))
+
+	// The new chain confirms one of them a block earlier
+	moved := &pb.Transaction{Hash: "Tx1Hash", BlockHeight: 1, Sender: "NSender", Receiver: "NReceiver", Amount: 1}
+	batch := sm.NewBlockBatch()
+	assert.NilError(t, batch.PutBlock(&pb.Block{Height: 1, Hash: "Other1Hash"}, []*pb.Transaction{moved}))
+	assert.NilError(t, batch.PutBlock(&pb.Block{Height: 2, Hash: "Other2Hash"}, nil))
+	assert.NilError(t, batch.Write())
+
+	transactions, err := sm.GetBlockTransactions(2)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(transactions))
+	_, err = sm.GetTransactionByHash("Tx2Hash")
+	assert.Assert(t, errors.Is(err, store.ErrNotFound))
+
+	found, err := sm.GetTransactionByHash("Tx1Hash")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), found.BlockHeight)
+
+	totals, err := sm.GetAddressTotals("NReceiver")
+	assert.NilError(t, err)
+	assert.Equal(t, store.AddressTotals{Received: 1, Transactions: 1}, totals)
+}
+
+// Test that indexes are rebuilt for values stored without them
+func TestReindex(t *testing.T) {
+	t.Parallel()
+
+	sm := newTempStorage(t)
+	assert.NilError(t, sm.BlockStorage().Put(sm.BlockKey(0), &pb.Block{Height: 0, Hash: "Block0Hash"}))
+	assert.NilError(t, sm.TransactionStorage().Put(sm.TransactionKey(0, "Tx0Hash"), &pb.Transaction{Hash: "Tx0Hash"}))
+
+	inSync, err := sm.IndexesInSync()
+	assert.NilError(t, err)
+	assert.Assert(t, !inSync)
+
+	assert.NilError(t, sm.Reindex())
+
+	inSync, err = sm.IndexesInSync()
+	assert.NilError(t, err)
+	assert.Assert(t, inSync)
+
+	_, err = sm.GetBlockByHash("Block0Hash")
+	assert.NilError(t, err)
+	_, err = sm.GetTransactionByHash("Tx0Hash")
+	assert.NilError(t, err)
+
+	// Only the version marker is checked
+	batch := sm.NewBlockBatch()
+	assert.NilError(t, batch.PutIndexesVersion())
+	assert.NilError(t, batch.Write())
+	inSync, err = sm.IndexesInSync()
+	assert.NilError(t, err)
+	assert.Assert(t, inSync)
+	assert.NilError(t, sm.StatusStorage().Delete(pb.IndexesKey))
+	inSync, err = sm.IndexesInSync()
+	assert.NilError(t, err)
+	assert.Assert(t, !inSync)
+}
+
+// Test that pending transactions are found by sender, and reindexed
+func TestPendingSenderIndex(t *testing.T) {
+	t.Parallel()
+
+	sm := newTempStorage(t)
+	assert.NilError(t, sm.PutPendingTransaction(&pb.Transaction{Hash: "Tx1Hash", Sender: "NSender", Amount: 1}))
+	assert.NilError(t, sm.PutPendingTransaction(&pb.Transaction{Hash: "Tx2Hash", Sender: "NSender", Amount: 2}))
+	assert.NilError(t, sm.PutPendingTransaction(&pb.Transaction{Hash: "Tx3Hash", Sender: "NOther", Amount: 3}))
+	// Stored before the index
+	assert.NilError(t, sm.PendingTransactionStorage().Put(sm.TransactionKey(0, "Tx4Hash"), &pb.Transaction{Hash: "Tx4Hash", Sender: "NSender", Amount: 4}))
+
+	pending, err := sm.GetPendingTransactionsBySender("NSender")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(pending))
+	assert.Equal(t, "Tx1Hash", pending[0].Hash)
+
+	assert.NilError(t, sm.Reindex())
+	pending, err = sm.GetPendingTransactionsBySender("NSender")
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(pending))
+
+	// Transactions no longer pending are skipped
+	assert.NilError(t, sm.PendingTransactionStorage().Delete(sm.TransactionKey(0, "Tx1Hash")))
+	pending, err = sm.GetPendingTransactionsBySender("NSender")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(pending))
+}
+
+// Test that confirming a transaction drops it from the pending ones
+func TestPendingConfirmed(t *testing.T) {
+	t.Parallel()
+
+	sm := newTempStorage(t)
+	assert.NilError(t, sm.PutPendingTransaction(&pb.Transaction{Hash: "Tx1Hash", Sender: "NSender", Amount: 1}))
+	assert.NilError(t, sm.PutPendingTransaction(&pb.Transaction{Hash: "Tx2Hash", Sender: "NSender", Amount: 2}))
+
+	confirmed := &pb.Transaction{Hash: "Tx1Hash", BlockHeight: 5, Sender: "NSender", Amount: 1}
+	assert.NilError(t, sm.PutBlock(&pb.Block{Height: 5, Hash: "Block5Hash"}, []*pb.Transaction{confirmed}))
+
+	known, err := sm.PendingTransactionStorage().Has(sm.TransactionKey(0, "Tx1Hash"))
+	assert.NilError(t, err)
+	assert.Assert(t, !known)
+	indexed, err := sm.GetDB().Has([]byte(store.PendingSenderPrefix+"NSender:"+sm.TransactionKey(0, "Tx1Hash")), nil)
+	assert.NilError(t, err)
+	assert.Assert(t, !indexed)
+
+	pending, err := sm.GetPendingTransactionsBySender("NSender")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(pending))
+	assert.Equal(t, "Tx2Hash", pending[0].Hash)
+}
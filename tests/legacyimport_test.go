@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	"github.com/Friends-Of-Noso/NosoGo/legacyimport"
+	"github.com/Friends-Of-Noso/NosoGo/legacynet"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/store"
+)
+
+// Extends the legacy chain of a NOSODATA folder up to a block, rewriting the
+// headers. Takes and returns the hashes of the blocks.
+func writeLegacyChain(t *testing.T, folder string, hashes []string, to int64) []string {
+	assert.NilError(t, os.MkdirAll(filepath.Join(folder, legacynet.BlocksFolderName), 0755))
+
+	for number := int64(len(hashes)); number <= to; number++ {
+		lastHash := "GENESIS"
+		if number > 0 {
+			lastHash = hashes[number-1]
+		}
+		data, err := legacy.EncodeBlock(newLegacyBlock(number, lastHash))
+		assert.NilError(t, err)
+		path := filepath.Join(folder, legacynet.BlocksFolderName, strconv.FormatInt(number, 10)+legacynet.BlockFileExt)
+		assert.NilError(t, os.WriteFile(path, data, 0644))
+		sum := md5.Sum(data)
+		hashes = append(hashes, strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+
+	var headers []legacy.HeaderEntry
+	for number, hash := range hashes {
+		headers = append(headers, legacy.HeaderEntry{Block: int32(number), BlockHash: hash, SummaryHash: "SUM"})
+	}
+	data, err := legacy.EncodeHeaders(headers)
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(filepath.Join(folder, legacynet.HeadersFileName), data, 0644))
+	return hashes
+}
+
+// Test legacy blocks are imported with their transactions and the import resumes
+func TestLegacyImport(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	hashes := writeLegacyChain(t, folder, nil, 4)
+	sm := newTempStorage(t)
+	// The block zero a new node creates is replaced
+	assert.NilError(t, sm.PutBlock(pb.NewBlockZero(), nil))
+	assert.NilError(t, sm.StatusStorage().Put(pb.StatusKey, &pb.Status{LastBlock: 0, LastHash: "BZERO"}))
+
+	var batches []uint64
+	importer := legacyimport.NewImporter(sm, folder, 2, func(last uint64) { batches = append(batches, last) })
+	result, err := importer.Import(context.Background())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, legacyimport.Result{First: 0, Last: 4, Blocks: 5, Transactions: 10}, result)
+	assert.DeepEqual(t, []uint64{1, 3, 4}, batches)
+
+	blocks, err := sm.GetBlocks(0, 4)
+	assert.NilError(t, err)
+	assert.Equal(t, 5, len(blocks))
+	assert.Equal(t, hashes[0], blocks[0].Hash)
+	assert.Equal(t, "", blocks[0].PreviousHash)
+	assert.Equal(t, hashes[3], blocks[4].PreviousHash)
+	_, err = sm.GetBlockByHash("BZERO")
+	assert.Assert(t, errors.Is(err, store.ErrNotFound))
+
+	transactions, err := sm.GetBlockTransactions(2)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(transactions))
+	transfer, err := sm.GetTransactionByHash("tR2" + addressN)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), transfer.BlockHeight)
+	assert.Equal(t, "TRFR", transfer.Type)
+	assert.Equal(t, uint64(100000000), transfer.Amount)
+	assert.Equal(t, "N4ZR3fKhTUod34evnEcDQX3i6XufBDU", transfer.Sender)
+	assert.Equal(t, uint64(10000), transfer.Fee)
+	for _, transaction := range transactions {
+		if transaction.Type == pb.TransactionTypeCoinbase {
+			assert.Equal(t, uint64(5000010000), transaction.Amount)
+			assert.Equal(t, addressN, transaction.Receiver)
+		}
+	}
+
+	// The sender of the orders paid their fees, the miner got them with the reward
+	sender, err := sm.GetAddressTotals("N4ZR3fKhTUod34evnEcDQX3i6XufBDU")
+	assert.NilError(t, err)
+	assert.Equal(t, store.AddressTotals{Sent: 5 * (100000000 + 10000), Transactions: 5}, sender)
+	miner, err := sm.GetAddressTotals(addressN)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(5*(5000010000+100000000)), miner.Received)
+	assert.Equal(t, uint64(0), miner.Sent)
+
+	status := &pb.Status{}
+	assert.NilError(t, sm.StatusStorage().Get(pb.StatusKey, status))
+	assert.Equal(t, uint64(4), status.LastBlock)
+	assert.Equal(t, hashes[4], status.LastHash)
+	inSync, err := sm.IndexesInSync()
+	assert.NilError(t, err)
+	assert.Assert(t, inSync)
+
+	// Nothing new, then the blocks added since
+	result, err = importer.Import(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(0), result.Blocks)
+	hashes = writeLegacyChain(t, folder, hashes, 6)
+	result, err = importer.Import(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(5), result.First)
+	assert.Equal(t, uint64(6), result.Last)
+	checkpoint, err := importer.Checkpoint()
+	assert.NilError(t, err)
+	assert.Equal(t, hashes[6], checkpoint.LastHash)
+
+	// A cancelled import stores nothing more
+	writeLegacyChain(t, folder, hashes, 7)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = importer.Import(ctx)
+	assert.Assert(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, uint64(0), result.Blocks)
+}
+
+// Test blocks not matching the headers or the chain are refused
+func TestLegacyImportMismatch(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	hashes := writeLegacyChain(t, folder, nil, 2)
+	data, err := legacy.EncodeBlock(newLegacyBlock(2, "BADHASH"))
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(filepath.Join(folder, legacynet.BlocksFolderName, "2.blk"), data, 0644))
+
+	sm := newTempStorage(t)
+	result, err := legacyimport.NewImporter(sm, folder, 0, nil).Import(context.Background())
+	assert.Assert(t, errors.Is(err, legacyimport.ErrChainMismatch))
+	assert.Equal(t, uint64(1), result.Last)
+
+	// The blocks before stay, and without headers the block still doesn't follow them
+	assert.NilError(t, os.Remove(filepath.Join(folder, legacynet.HeadersFileName)))
+	result, err = legacyimport.NewImporter(sm, folder, 0, nil).Import(context.Background())
+	assert.Assert(t, errors.Is(err, legacyimport.ErrChainMismatch))
+	assert.Equal(t, uint64(0), result.Blocks)
+	checkpoint, err := legacyimport.NewImporter(sm, folder, 0, nil).Checkpoint()
+	assert.NilError(t, err)
+	assert.Equal(t, hashes[1], checkpoint.LastHash)
+
+	// A database holding another chain isn't imported into
+	other := newTempStorage(t)
+	assert.NilError(t, other.StatusStorage().Put(pb.StatusKey, &pb.Status{LastBlock: 3, LastHash: "B3"}))
+	_, err = legacyimport.NewImporter(other, folder, 0, nil).Import(context.Background())
+	assert.Assert(t, errors.Is(err, legacyimport.ErrChainExists))
+}
+
+// Test a headers entry far past the chain doesn't get in the way
+func TestLegacyImportFarHeaders(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	hashes := writeLegacyChain(t, folder, nil, 1)
+	data, err := legacy.EncodeHeaders([]legacy.HeaderEntry{
+		{Block: 0, BlockHash: hashes[0], SummaryHash: "SUM"},
+		{Block: math.MaxInt32, BlockHash: "FAR", SummaryHash: "SUM"},
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(filepath.Join(folder, legacynet.HeadersFileName), data, 0644))
+
+	result, err := legacyimport.NewImporter(newTempStorage(t), folder, 0, nil).Import(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), result.Blocks)
+}
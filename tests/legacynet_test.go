@@ -0,0 +1,427 @@
+package tests
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	"github.com/Friends-Of-Noso/NosoGo/legacynet"
+)
+
+// A Pascal node status at block 2, without headers or masternodes
+const cLegacyPong = "PSK 2 0.4.4 1700000000 $PONG 5 2 HASH2 null 0 null 3 8080 null 0 null 0 null null null"
+
+// Creates a valid order of one transfer
+func newLegacyOrder(t *testing.T, id string) *legacynet.Order {
+	priv, err := btcec.NewPrivateKey()
+	assert.NilError(t, err)
+	publicKey := base64.StdEncoding.EncodeToString(priv.PubKey().SerializeUncompressed())
+	return &legacynet.Order{Transfers: []legacynet.Transfer{{
+		Type:      "TRFR",
+		OrderID:   id,
+		Lines:     1,
+		Timestamp: 1700000000,
+		Reference: "null",
+		Line:      1,
+		Sender:    publicKey,
+		Address:   legacy.GetAddressFromPublicKey(publicKey, 0),
+		Receiver:  addressN,
+		Fee:       1000000,
+		Amount:    500000000,
+		Signature: "c2lnbmF0dXJl",
+		ID:        "tR" + id,
+	}}}
+}
+
+// Test lines parse back as written, with and without header
+func TestLegacyMessages(t *testing.T) {
+	t.Parallel()
+
+	msg, err := legacynet.ParseMessage(cLegacyPong + "\r\n")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, msg.Protocol)
+	assert.Equal(t, "0.4.4", msg.Version)
+	assert.Equal(t, int64(1700000000), msg.Time)
+	assert.Equal(t, legacynet.CmdPong, msg.Command)
+	assert.Equal(t, cLegacyPong, msg.String())
+
+	status, err := legacynet.ParseNodeStatus(msg.Args)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), status.LastBlock)
+	assert.Equal(t, "HASH2", status.LastHash)
+	assert.Equal(t, 3, status.ConStatus)
+	assert.Equal(t, 8080, status.Port)
+	again, err := legacynet.ParseNodeStatus(status.Args())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, status, again)
+
+	// Older nodes send fewer fields
+	_, err = legacynet.ParseNodeStatus(msg.Args[:10])
+	assert.NilError(t, err)
+	_, err = legacynet.ParseNodeStatus(msg.Args[:9])
+	assert.Assert(t, errors.Is(err, legacynet.ErrInvalidMessage))
+
+	// The handshake is a header alone, with the address where the protocol goes
+	msg, err = legacynet.ParseMessage("PSK 192.0.2.1 0.4.4 1700000000")
+	assert.NilError(t, err)
+	assert.Equal(t, "", msg.Command)
+	assert.Equal(t, "0.4.4", msg.Version)
+
+	msg, err = legacynet.ParseMessage("NODESTATUS")
+	assert.NilError(t, err)
+	assert.Equal(t, legacynet.CmdNodeStatus, msg.Command)
+	assert.Equal(t, "NODESTATUS", msg.String())
+
+	_, err = legacynet.ParseMessage("PSK 2 0.4.4")
+	assert.Assert(t, errors.Is(err, legacynet.ErrInvalidMessage))
+	_, err = legacynet.ParseMessage("  ")
+	assert.Assert(t, errors.Is(err, legacynet.ErrInvalidMessage))
+}
+
+// Test orders parse back as written and are checked
+func TestLegacyOrders(t *testing.T) {
+	t.Parallel()
+
+	order := newLegacyOrder(t, "OR1")
+	order.Transfers = append(order.Transfers, newLegacyOrder(t, "OR1").Transfers[0])
+	args := order.Args()
+	assert.Equal(t, "2", args[0])
+	assert.Equal(t, "$TRFR", args[1])
+
+	parsed, err := legacynet.ParseOrder(args)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, order, parsed)
+	assert.Equal(t, "OR1", parsed.ID())
+	assert.NilError(t, parsed.Validate())
+
+	args[0] = "3"
+	_, err = legacynet.ParseOrder(args)
+	assert.Assert(t, errors.Is(err, legacynet.ErrInvalidMessage))
+	_, err = legacynet.ParseOrder(order.Args()[:20])
+	assert.Assert(t, errors.Is(err, legacynet.ErrInvalidMessage))
+
+	other := newLegacyOrder(t, "OR2")
+	mixed := &legacynet.Order{Transfers: []legacynet.Transfer{order.Transfers[0], other.Transfers[0]}}
+	_, err = legacynet.ParseOrder(mixed.Args())
+	assert.Assert(t, errors.Is(err, legacynet.ErrInvalidMessage))
+
+	// The address must be the one of the public key
+	other.Transfers[0].Address = addressN
+	assert.Assert(t, errors.Is(other.Validate(), legacynet.ErrInvalidMessage))
+}
+
+// A Pascal node the bridge connects to, driven by the test
+type fakeLegacyNode struct {
+	listener net.Listener
+	conn     net.Conn
+	reader   *bufio.Reader
+}
+
+func newFakeLegacyNode(t *testing.T) *fakeLegacyNode {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	return &fakeLegacyNode{listener: listener}
+}
+
+func (f *fakeLegacyNode) accept(t *testing.T) {
+	conn, err := f.listener.Accept()
+	assert.NilError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	f.conn, f.reader = conn, bufio.NewReader(conn)
+}
+
+func (f *fakeLegacyNode) readMessage(t *testing.T) *legacynet.Message {
+	assert.NilError(t, f.conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	line, err := f.reader.ReadString('\n')
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasSuffix(line, "\r\n"))
+	msg, err := legacynet.ParseMessage(line)
+	assert.NilError(t, err)
+	return msg
+}
+
+// Reads messages until one with the command, skipping pings
+func (f *fakeLegacyNode) expect(t *testing.T, command string) *legacynet.Message {
+	for {
+		msg := f.readMessage(t)
+		if msg.Command == command {
+			return msg
+		}
+		assert.Equal(t, legacynet.CmdPing, msg.Command)
+	}
+}
+
+func (f *fakeLegacyNode) send(t *testing.T, line string) {
+	_, err := f.conn.Write([]byte(line + "\r\n"))
+	assert.NilError(t, err)
+}
+
+func (f *fakeLegacyNode) sendFile(t *testing.T, command string, data []byte) {
+	buf := []byte(command + "\r\n")
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	_, err := f.conn.Write(append(buf, data...))
+	assert.NilError(t, err)
+}
+
+// Encodes the legacy chain up to a block, each block following the file before
+func encodeLegacyChain(t *testing.T, to int64) [][]byte {
+	var files [][]byte
+	lastHash := "GENESIS"
+	for number := int64(0); number <= to; number++ {
+		data, err := legacy.EncodeBlock(newLegacyBlock(number, lastHash))
+		assert.NilError(t, err)
+		files = append(files, data)
+		sum := md5.Sum(data)
+		lastHash = strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+	return files
+}
+
+// Zips block files of a chain as Pascal nodes send them
+func zipLegacyBlocks(t *testing.T, chain [][]byte, heights ...int) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, height := range heights {
+		writer, err := archive.Create(fmt.Sprintf("NOSODATA/BLOCKS/%d.blk", height))
+		assert.NilError(t, err)
+		_, err = writer.Write(chain[height])
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, archive.Close())
+	return buf.Bytes()
+}
+
+// Returns a local port nobody listens on
+func freeLegacyPort(t *testing.T) int32 {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	return int32(listener.Addr().(*net.TCPAddr).Port)
+}
+
+// Starts a bridge, stopped when the test ends
+func startTestBridge(t *testing.T, config *cfg.LegacyConfig, dataFolder string, onOrder legacynet.OrderHandler) *legacynet.Bridge {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	bridge, err := legacynet.NewBridge(ctx, func() {}, &wg, fmt.Sprintf("127.0.0.1:%d", config.Port), config, dataFolder, onOrder)
+	assert.NilError(t, err)
+	wg.Add(1)
+	go bridge.Start()
+	t.Cleanup(func() {
+		cancel()
+		bridge.ShutDown()
+		wg.Wait()
+	})
+	return bridge
+}
+
+// Test the bridge follows the chain of a Pascal node, answers status
+// requests and relays orders both ways
+func TestLegacyBridge(t *testing.T) {
+	t.Parallel()
+
+	node := newFakeLegacyNode(t)
+	config := cfg.DefaultLegacyConfig()
+	config.Port = freeLegacyPort(t)
+	config.Nodes = []string{node.listener.Addr().String()}
+	dataFolder := t.TempDir()
+	orders := make(chan string, 1)
+	bridge := startTestBridge(t, config, dataFolder, func(line string) { orders <- line })
+
+	// Handshake, then a ping
+	node.accept(t)
+	handshake := node.readMessage(t)
+	assert.Equal(t, "", handshake.Command)
+	assert.Equal(t, legacynet.ProgramVersion, handshake.Version)
+	ping := node.expect(t, legacynet.CmdPing)
+	_, err := legacynet.ParseNodeStatus(ping.Args)
+	assert.NilError(t, err)
+
+	// Behind the node, the bridge asks for its blocks
+	node.send(t, cLegacyPong)
+	request := node.expect(t, legacynet.CmdLastBlock)
+	assert.DeepEqual(t, []string{"0"}, request.Args)
+	// Blocks after a missing one are left out
+	chain := encodeLegacyChain(t, 4)
+	node.sendFile(t, legacynet.CmdBlockZip, zipLegacyBlocks(t, chain, 0, 1, 2, 4))
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if last, ok := bridge.LastBlock(); ok && last == 2 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for the blocks")
+	})
+	block, err := os.ReadFile(filepath.Join(dataFolder, legacynet.BlocksFolderName, "2.blk"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, chain[2], block)
+	assert.Equal(t, uint64(2), bridge.Consensus().LastBlock)
+
+	// Files nobody asked for are skipped, the orders below still get through
+	node.sendFile(t, legacynet.CmdBlockZip, zipLegacyBlocks(t, chain, 3))
+	node.sendFile(t, legacynet.CmdResumenFile, []byte("headers"))
+
+	// Wallets get the status of the chain followed
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", config.Port))
+	assert.NilError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("NODESTATUS\r\n"))
+	assert.NilError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	assert.NilError(t, err)
+	msg, err := legacynet.ParseMessage(line)
+	assert.NilError(t, err)
+	assert.Equal(t, legacynet.CmdNodeStatus, msg.Command)
+	status, err := legacynet.ParseNodeStatus(msg.Args)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), status.LastBlock)
+	assert.Equal(t, 1, status.Connections)
+
+	// Orders from Pascal nodes go to the NosoGo network, once
+	order := legacynet.NewMessage(legacynet.CmdOrder, newLegacyOrder(t, "OR1").Args()...)
+	node.send(t, order.String())
+	select {
+	case relayed := <-orders:
+		msg, err := legacynet.ParseMessage(relayed)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, order.Args, msg.Args)
+		assert.NilError(t, bridge.RelayOrder(relayed))
+	case <-time.After(5 * time.Second):
+		t.Fatal("order not relayed")
+	}
+
+	// Orders from the NosoGo network go to Pascal nodes
+	other := legacynet.NewMessage(legacynet.CmdOrder, newLegacyOrder(t, "OR2").Args()...)
+	assert.NilError(t, bridge.RelayOrder(other.String()))
+	relayed := node.expect(t, legacynet.CmdOrder)
+	assert.DeepEqual(t, other.Args, relayed.Args)
+
+	assert.Assert(t, errors.Is(bridge.RelayOrder("PSK 2 0.4.4 1700000000 $PING"), legacynet.ErrInvalidMessage))
+	peers := bridge.Peers()
+	assert.Equal(t, 1, len(peers))
+	assert.Equal(t, node.listener.Addr().String(), peers[0].Name())
+	assert.Assert(t, !peers[0].Inbound())
+
+	last, _ := bridge.LastBlock()
+	assert.Equal(t, uint64(2), last)
+	for _, name := range []string{filepath.Join(legacynet.BlocksFolderName, "3.blk"), filepath.Join(legacynet.BlocksFolderName, "4.blk"), legacynet.HeadersFileName} {
+		_, err := os.Stat(filepath.Join(dataFolder, name))
+		assert.Assert(t, os.IsNotExist(err), name)
+	}
+}
+
+// Test blocks not following the chain are refused and their sender dropped
+func TestLegacyBridgeForeignBlocks(t *testing.T) {
+	t.Parallel()
+
+	node := newFakeLegacyNode(t)
+	config := cfg.DefaultLegacyConfig()
+	config.Port = 0
+	config.Nodes = []string{node.listener.Addr().String()}
+	dataFolder := t.TempDir()
+	bridge := startTestBridge(t, config, dataFolder, nil)
+
+	node.accept(t)
+	node.readMessage(t)
+	node.send(t, cLegacyPong)
+	node.expect(t, legacynet.CmdLastBlock)
+	chain := encodeLegacyChain(t, 2)
+	foreign, err := legacy.EncodeBlock(newLegacyBlock(2, "F0E1D2C3B4A5968778695A4B3C2D1E0F"))
+	assert.NilError(t, err)
+	chain[2] = foreign
+	node.sendFile(t, legacynet.CmdBlockZip, zipLegacyBlocks(t, chain, 0, 1, 2))
+
+	// Dropped after the blocks that follow
+	assert.NilError(t, node.conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		if _, err := node.reader.ReadString('\n'); err != nil {
+			break
+		}
+	}
+	last, ok := bridge.LastBlock()
+	assert.Assert(t, ok)
+	assert.Equal(t, uint64(1), last)
+	_, err = os.Stat(filepath.Join(dataFolder, legacynet.BlocksFolderName, "2.blk"))
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+// Test the headers are checked against the blocks saved, and the masternodes
+// against the hash of the network, before being written
+func TestLegacyBridgeFiles(t *testing.T) {
+	t.Parallel()
+
+	node := newFakeLegacyNode(t)
+	config := cfg.DefaultLegacyConfig()
+	config.Port = 0
+	config.Nodes = []string{node.listener.Addr().String()}
+	dataFolder := t.TempDir()
+	startTestBridge(t, config, dataFolder, nil)
+
+	chain := encodeLegacyChain(t, 2)
+	hash := func(data []byte) string {
+		sum := md5.Sum(data)
+		return strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+	var entries []legacy.HeaderEntry
+	for height, block := range chain {
+		entries = append(entries, legacy.HeaderEntry{Block: int32(height), BlockHash: hash(block), SummaryHash: "SUMMARY"})
+	}
+	headers, err := legacy.EncodeHeaders(entries)
+	assert.NilError(t, err)
+	mns := []byte("2 192.0.2.1;8080:N3VJ4Zh8dQ3Kmg8NAJqsRRMZP6pbtCH:0")
+	pong := func(headersHash string, mnsHash string) string {
+		return fmt.Sprintf("PSK 2 0.4.4 1700000000 $PONG 5 2 %s null 0 %s 3 8080 %s 1 null 0 null null null", hash(chain[2]), headersHash, mnsHash)
+	}
+
+	node.accept(t)
+	node.readMessage(t)
+	node.send(t, pong("HEADERS1", hash(mns)[:5]))
+	node.expect(t, legacynet.CmdLastBlock)
+	node.sendFile(t, legacynet.CmdBlockZip, zipLegacyBlocks(t, chain, 0, 1, 2))
+	node.expect(t, legacynet.CmdGetResumen)
+	node.sendFile(t, legacynet.CmdResumenFile, headers)
+	node.expect(t, legacynet.CmdGetMNs)
+	node.sendFile(t, legacynet.CmdMNFile, mns)
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if _, err := os.Stat(filepath.Join(dataFolder, legacynet.MNsFileName)); err == nil {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for the masternodes")
+	})
+
+	// Headers with another hash for a block we have are refused, and their sender dropped
+	entries[1].BlockHash = strings.Repeat("0", 32)
+	foreign, err := legacy.EncodeHeaders(entries)
+	assert.NilError(t, err)
+	node.send(t, pong("HEADERS2", hash(mns)[:5]))
+	node.expect(t, legacynet.CmdGetResumen)
+	node.sendFile(t, legacynet.CmdResumenFile, foreign)
+	assert.NilError(t, node.conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		if _, err := node.reader.ReadString('\n'); err != nil {
+			break
+		}
+	}
+	saved, err := os.ReadFile(filepath.Join(dataFolder, legacynet.HeadersFileName))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, headers, saved)
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/dns"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Creates a DNS server with seeds spread over a few networks
+func newNearestDNS(t *testing.T) *dns.DNS {
+	seeds := pb.NewPeerList()
+	now := time.Now().Unix()
+	seeds.Add(&pb.PeerInfo{Id: "QmSameSubnet", Address: "192.168.1.10", Port: 45050, Connected: true, LastSeen: now})
+	seeds.Add(&pb.PeerInfo{Id: "QmSameNetwork", Address: "192.168.7.10", Port: 45050, LastSeen: now})
+	seeds.Add(&pb.PeerInfo{Id: "QmFast", Address: "10.1.1.1", Port: 45050, Connected: true, LastSeen: now, LatencyMs: 5})
+	seeds.Add(&pb.PeerInfo{Id: "QmSlow", Address: "10.2.2.2", Port: 45050, Connected: true, LastSeen: now, LatencyMs: 900})
+	seeds.Add(&pb.PeerInfo{Id: "QmStale", Address: "192.168.1.11", Port: 45050, LastSeen: now - 3600})
+
+	return newTestDNSWithPeers(t, cfg.DefaultDNSConfig(), seeds, pb.NewPeerList(), nil)
+}
+
+// Asks for the nearest peers as the given client
+func getNearest(t *testing.T, server *dns.DNS, client string, query string) (*httptest.ResponseRecorder, []string) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/nearest"+query, nil)
+	req.RemoteAddr = client + ":40000"
+	req.Header.Set("Accept", pb.ContentTypeProtoBuf)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		return rec, nil
+	}
+
+	msg := &pb.DNSPeersResponse{}
+	assert.NilError(t, proto.Unmarshal(rec.Body.Bytes(), msg))
+	var ids []string
+	for _, peer := range msg.Peers {
+		ids = append(ids, peer.Id)
+	}
+	slices.Sort(ids)
+	return rec, ids
+}
+
+// Test that peers on the client's network come first and stale peers never
+func TestDNSNearestSubnet(t *testing.T) {
+	t.Parallel()
+
+	server := newNearestDNS(t)
+
+	_, ids := getNearest(t, server, "192.168.1.99", "?limit=1")
+	assert.DeepEqual(t, []string{"QmSameSubnet"}, ids)
+
+	_, ids = getNearest(t, server, "192.168.1.99", "?limit=2")
+	assert.DeepEqual(t, []string{"QmSameNetwork", "QmSameSubnet"}, ids)
+
+	_, ids = getNearest(t, server, "192.168.1.99", "")
+	assert.Equal(t, 4, len(ids))
+	assert.Assert(t, !slices.Contains(ids, "QmStale"))
+}
+
+// Test that the lowest latency wins when no peer shares the client's network
+func TestDNSNearestLatency(t *testing.T) {
+	t.Parallel()
+
+	_, ids := getNearest(t, newNearestDNS(t), "172.16.0.1", "?limit=1")
+	assert.DeepEqual(t, []string{"QmFast"}, ids)
+}
+
+// Test that bad parameters are refused
+func TestDNSNearestInvalid(t *testing.T) {
+	t.Parallel()
+
+	server := newNearestDNS(t)
+
+	rec, _ := getNearest(t, server, "172.16.0.1", "?limit=0")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = getNearest(t, server, "172.16.0.1", "?mode=dns")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
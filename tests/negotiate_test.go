@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Test the content type picked for Accept headers, the most specific range first
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", pb.ContentTypeJSON},
+		{"*/*", pb.ContentTypeJSON},
+		{"application/*", pb.ContentTypeJSON},
+		{"text/*", pb.ContentTypeText},
+		{"*/*, application/x-protobuf", pb.ContentTypeProtoBuf},
+		{"application/*, application/protobuf", pb.ContentTypeProtoBuf},
+		{"application/json;q=0.5, application/x-protobuf", pb.ContentTypeProtoBuf},
+		{"application/x-protobuf;q=0, */*", pb.ContentTypeJSON},
+		{"application/json;q=0, application/x-protobuf;q=0, */*;q=0.1", pb.ContentTypeText},
+		{"text/plain, application/json", pb.ContentTypeJSON},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept", test.accept)
+		got, err := pb.Negotiate(req)
+		assert.NilError(t, err, test.accept)
+		assert.Equal(t, test.want, got, test.accept)
+	}
+
+	for _, accept := range []string{"image/png", "application/json;q=0", "*/*;q=0"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept", accept)
+		_, err := pb.Negotiate(req)
+		assert.Assert(t, errors.Is(err, pb.ErrNotAcceptable), accept)
+	}
+}
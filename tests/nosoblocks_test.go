@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+)
+
+// Creates a legacy block with an order, paying the rewards of its height
+func newLegacyBlock(number int64, lastBlockHash string) *legacy.Block {
+	block := &legacy.Block{
+		Number:        number,
+		TimeStart:     1700000000 + number*600,
+		TimeEnd:       1700000000 + number*600 + 590,
+		TimeTotal:     590,
+		TimeLast20:    600,
+		Difficult:     100,
+		TargetHash:    "TARGET",
+		Solution:      "SOLUTION",
+		LastBlockHash: lastBlockHash,
+		NxtBlkDiff:    101,
+		AccountMiner:  addressN,
+		MinerFee:      10000,
+		Reward:        5000000000,
+		Orders: []legacy.BlockOrder{{
+			Block:      int32(number),
+			OrderID:    fmt.Sprintf("OR%d%s", number, addressN),
+			OrderLines: 1,
+			OrderType:  "TRFR",
+			TimeStamp:  1700000000 + number*600 + 10,
+			Reference:  "null",
+			TrxLine:    1,
+			Sender:     "BPublicKey",
+			Address:    "N4ZR3fKhTUod34evnEcDQX3i6XufBDU",
+			Receiver:   addressN,
+			Fee:        10000,
+			Amount:     100000000,
+			Signature:  "MEUCIQSignature",
+			TrfrID:     fmt.Sprintf("tR%d%s", number, addressN),
+		}},
+	}
+	if legacy.HasPoS(number) {
+		block.PoSReward = 2000
+		block.PoSAddresses = []string{addressN, "N4ZR3fKhTUod34evnEcDQX3i6XufBDU"}
+	}
+	if legacy.HasMNs(number) {
+		block.MNsReward = 3000
+		block.MNAddresses = []string{addressN}
+	}
+	return block
+}
+
+// Test block files and headers decode as encoded
+func TestLegacyBlockFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, number := range []int64{1, legacy.PoSBlockStart + 1, legacy.MNBlockStart, legacy.PoSBlockEnd} {
+		block := newLegacyBlock(number, "F0E1D2C3B4A5968778695A4B3C2D1E0F")
+		data, err := legacy.EncodeBlock(block)
+		assert.NilError(t, err)
+		decoded, err := legacy.DecodeBlock(data)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, block, decoded)
+
+		_, err = legacy.DecodeBlock(data[:len(data)-1])
+		assert.Assert(t, errors.Is(err, legacy.ErrInvalidBlock))
+	}
+
+	block := newLegacyBlock(1, "")
+	block.AccountMiner = string(make([]byte, 41))
+	_, err := legacy.EncodeBlock(block)
+	assert.Assert(t, errors.Is(err, legacy.ErrInvalidBlock))
+
+	headers := []legacy.HeaderEntry{
+		{Block: 0, BlockHash: "4E8A4743AA6083F3833DDA1216FE3717", SummaryHash: "9B2A3C"},
+		{Block: 1, BlockHash: "1A2B3C", SummaryHash: "4D5E6F"},
+	}
+	data, err := legacy.EncodeHeaders(headers)
+	assert.NilError(t, err)
+	assert.Equal(t, 2*legacy.HeaderEntrySize, len(data))
+	decoded, err := legacy.DecodeHeaders(data)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, headers, decoded)
+
+	_, err = legacy.DecodeHeaders(data[:len(data)-1])
+	assert.Assert(t, errors.Is(err, legacy.ErrInvalidHeaders))
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+)
+
+// Creates a legacy wallet entry with base64 keys, as the Noso wallet does
+func newLegacyWalletEntry(t *testing.T) legacy.WalletEntry {
+	priv, err := btcec.NewPrivateKey()
+	assert.NilError(t, err)
+	publicKey := base64.StdEncoding.EncodeToString(priv.PubKey().SerializeUncompressed())
+	return legacy.WalletEntry{
+		Hash:       legacy.GetAddressFromPublicKey(publicKey, 0),
+		PublicKey:  publicKey,
+		PrivateKey: base64.StdEncoding.EncodeToString(priv.Serialize()),
+		Balance:    1_250_000_000,
+		Score:      1,
+		LastOP:     1700000000,
+	}
+}
+
+// Test wallet.pkw files are read back as written, and their addresses verified
+func TestLegacyWalletFile(t *testing.T) {
+	t.Parallel()
+
+	entries := []legacy.WalletEntry{newLegacyWalletEntry(t), newLegacyWalletEntry(t)}
+	entries[1].Custom = "alias"
+	entries[1].Pending = -5
+
+	data, err := legacy.EncodeWallet(entries)
+	assert.NilError(t, err)
+	assert.Equal(t, 2*legacy.WalletEntrySize, len(data))
+	// Short strings start with their length
+	assert.Equal(t, byte(len(entries[0].Hash)), data[0])
+	assert.Equal(t, entries[0].Hash, string(data[1:1+len(entries[0].Hash)]))
+
+	decoded, err := legacy.DecodeWallet(data)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, entries, decoded)
+
+	_, err = legacy.DecodeWallet(data[:len(data)-1])
+	assert.Assert(t, errors.Is(err, legacy.ErrInvalidWallet))
+	data[0] = 41
+	_, err = legacy.DecodeWallet(data)
+	assert.Assert(t, errors.Is(err, legacy.ErrInvalidWallet))
+
+	file := filepath.Join(t.TempDir(), "wallet.pkw")
+	assert.NilError(t, legacy.WriteWalletFile(file, entries))
+	assert.Assert(t, errors.Is(legacy.WriteWalletFile(file, entries), os.ErrExist))
+	read, err := legacy.ReadWalletFile(file)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, entries, read)
+
+	// An address that isn't the one of its key is rejected
+	entries[0].Hash = addressN
+	mismatched := filepath.Join(t.TempDir(), "wallet.pkw")
+	assert.NilError(t, legacy.WriteWalletFile(mismatched, entries))
+	_, err = legacy.ReadWalletFile(mismatched)
+	assert.Assert(t, errors.Is(err, legacy.ErrInvalidWallet))
+}
@@ -86,3 +86,123 @@ func TestPeerListDisconnect(t *testing.T) {
 	assert.Equal(t, false, peer.Connected)
 	assert.Equal(t, "", peer.Direction)
 }
+
+// Test connecting a peer
+func TestPeerListConnect(t *testing.T) {
+	t.Parallel()
+
+	peerList := pb.NewPeerList()
+	peer := &pb.PeerInfo{
+		Address: "0.0.0.0",
+		Port:    8080,
+		Id:      "QmTesting",
+		Mode:    "node",
+	}
+	peerList.Add(peer)
+	peerList.Connect(peer.Id, pb.DirectionOutbound, 42)
+	assert.Equal(t, true, peerList.Connected(peer.Id))
+	assert.Equal(t, pb.DirectionOutbound, peer.Direction)
+	assert.Equal(t, int64(42), peer.LastSeen)
+
+	// Snapshots don't change along with the list
+	snapshot := peerList.Peers()[peer.Id]
+	got, ok := peerList.Get(peer.Id)
+	assert.Assert(t, ok)
+	peerList.Seen(peer.Id, 43)
+	assert.Equal(t, int64(42), snapshot.LastSeen)
+	assert.Equal(t, int64(42), got.LastSeen)
+}
+
+// Test removing stale peers
+func TestPeerListRemoveStale(t *testing.T) {
+	t.Parallel()
+
+	peerList := pb.NewPeerList()
+	peerList.Add(&pb.PeerInfo{
+		Id:       "QmStale",
+		LastSeen: 10,
+	})
+	peerList.Add(&pb.PeerInfo{
+		Id:       "QmFresh",
+		LastSeen: 100,
+	})
+	peerList.Seen("QmStale", 20)
+
+	removed := peerList.RemoveStale(50)
+	assert.DeepEqual(t, []string{"QmStale"}, removed)
+	assert.Equal(t, 1, peerList.Len())
+	assert.Equal(t, false, peerList.Has("QmStale"))
+	assert.Equal(t, true, peerList.Has("QmFresh"))
+}
+
+// Creates a list of peers for the query tests
+func newQueryPeerList() *pb.PeerList {
+	peerList := pb.NewPeerList()
+	peerList.Add(&pb.PeerInfo{Id: "QmA", Address: "10.0.0.1", Mode: "seed", Connected: true, LastSeen: 30})
+	peerList.Add(&pb.PeerInfo{Id: "QmB", Address: "10.0.0.2", Mode: "node", LastSeen: 10})
+	peerList.Add(&pb.PeerInfo{Id: "QmC", Address: "fd00::1", Mode: "node", Connected: true, LastSeen: 20})
+	peerList.Add(&pb.PeerInfo{Id: "QmD", Address: "10.0.0.4", Mode: "node", LastSeen: 40})
+	peerList.Add(&pb.PeerInfo{Id: "QmE", Address: "10.0.0.5", Mode: "supernode", LastSeen: 50})
+	return peerList
+}
+
+// Collects the IDs of a query response
+func peerIDs(msg *pb.DNSPeersResponse) []string {
+	var ids []string
+	for _, peer := range msg.Peers {
+		ids = append(ids, peer.Id)
+	}
+	return ids
+}
+
+// Test paging with limit and offset
+func TestPeerListQueryOffset(t *testing.T) {
+	t.Parallel()
+
+	msg, err := newQueryPeerList().Query(pb.PeerQuery{Offset: 1, Limit: 2})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"QmB", "QmC"}, peerIDs(msg))
+	assert.Equal(t, uint32(5), msg.Total)
+}
+
+// Test walking the list with cursors
+func TestPeerListQueryCursor(t *testing.T) {
+	t.Parallel()
+
+	peerList := newQueryPeerList()
+	q := pb.PeerQuery{Sort: pb.PeerSortLastSeen, Descending: true, Limit: 2}
+
+	var ids []string
+	for {
+		msg, err := peerList.Query(q)
+		assert.NilError(t, err)
+		ids = append(ids, peerIDs(msg)...)
+		if msg.NextCursor == "" {
+			break
+		}
+		q.Cursor = msg.NextCursor
+	}
+	assert.DeepEqual(t, []string{"QmE", "QmD", "QmA", "QmC", "QmB"}, ids)
+}
+
+// Test filtering by connected state and address family
+func TestPeerListQueryFilter(t *testing.T) {
+	t.Parallel()
+
+	connected := true
+	msg, err := newQueryPeerList().Query(pb.PeerQuery{Connected: &connected, Family: pb.PeerFamilyIPv4})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"QmA"}, peerIDs(msg))
+
+	msg, err = newQueryPeerList().Query(pb.PeerQuery{Mode: "node"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"QmB", "QmC", "QmD"}, peerIDs(msg))
+}
+
+// Test an invalid query
+func TestPeerListQueryInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := newQueryPeerList().Query(pb.PeerQuery{Offset: 1, Cursor: "abc"})
+	assert.ErrorIs(t, err, pb.ErrInvalidPeerQuery)
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/dns"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Creates a peer with a fresh identity
+func newSignedPeer(t *testing.T) (crypto.PrivKey, *pb.PeerInfo) {
+	privateKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	assert.NilError(t, err)
+
+	id, err := peer.IDFromPrivateKey(privateKey)
+	assert.NilError(t, err)
+
+	return privateKey, &pb.PeerInfo{
+		Address: "10.0.0.1",
+		Port:    45050,
+		Id:      id.String(),
+		Mode:    "seed",
+	}
+}
+
+// Test a correctly signed registration
+func TestRegisterRequestValid(t *testing.T) {
+	t.Parallel()
+
+	privateKey, peerInfo := newSignedPeer(t)
+	req, err := dns.NewRegisterRequest(privateKey, peerInfo)
+	assert.NilError(t, err)
+	assert.NilError(t, dns.VerifyRegisterRequest(req))
+}
+
+// Test a registration that was changed after being signed
+func TestRegisterRequestTampered(t *testing.T) {
+	t.Parallel()
+
+	privateKey, peerInfo := newSignedPeer(t)
+	req, err := dns.NewRegisterRequest(privateKey, peerInfo)
+	assert.NilError(t, err)
+
+	req.Peer.Address = "10.0.0.2"
+	err = dns.VerifyRegisterRequest(req)
+	assert.Assert(t, errors.Is(err, dns.ErrRegisterInvalidSignature))
+}
+
+// Test a registration for someone else's peer ID
+func TestRegisterRequestWrongKey(t *testing.T) {
+	t.Parallel()
+
+	privateKey, peerInfo := newSignedPeer(t)
+	_, otherPeer := newSignedPeer(t)
+	peerInfo.Id = otherPeer.Id
+
+	req, err := dns.NewRegisterRequest(privateKey, peerInfo)
+	assert.NilError(t, err)
+
+	err = dns.VerifyRegisterRequest(req)
+	assert.Assert(t, errors.Is(err, dns.ErrRegisterInvalidKey))
+}
+
+// Test that banned peers can't register
+func TestRegisterBanned(t *testing.T) {
+	t.Parallel()
+
+	privateKey, peerInfo := newSignedPeer(t)
+	seeds := pb.NewPeerList()
+	server := newTestDNSWithPeers(t, cfg.DefaultDNSConfig(), seeds, pb.NewPeerList(), func(id string) bool {
+		return id == peerInfo.Id
+	})
+	req, err := dns.NewRegisterRequest(privateKey, peerInfo)
+	assert.NilError(t, err)
+	data, err := proto.Marshal(req)
+	assert.NilError(t, err)
+
+	httpReq := httptest.NewRequest(http.MethodPost, "/v1/register", bytes.NewReader(data))
+	httpReq.Header.Set("Content-Type", pb.ContentTypeProtoBuf)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httpReq)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Assert(t, !seeds.Has(peerInfo.Id))
+}
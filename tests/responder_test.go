@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	mdns "github.com/miekg/dns"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/dns"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+)
+
+// Captures the answer of the responder
+type testResponseWriter struct {
+	mdns.ResponseWriter
+	msg *mdns.Msg
+}
+
+func (w *testResponseWriter) LocalAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 53}
+}
+
+func (w *testResponseWriter) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 12345}
+}
+
+func (w *testResponseWriter) WriteMsg(msg *mdns.Msg) error {
+	w.msg = msg
+	return nil
+}
+
+// Sends a query to a responder with one connected and one disconnected seed
+func queryResponder(t *testing.T, name string, qtype uint16) *mdns.Msg {
+	var wg sync.WaitGroup
+
+	seeds := pb.NewPeerList()
+	seeds.Add(&pb.PeerInfo{Address: "10.0.0.1", Port: 45050, Id: "QmOld", LastSeen: 10})
+	seeds.Add(&pb.PeerInfo{Address: "10.0.0.2", Port: 45050, Id: "QmNew", LastSeen: 20, Connected: true})
+
+	responder, err := dns.NewResponder(context.Background(), func() {}, &wg, seeds, "seeds.example.com", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	req := new(mdns.Msg)
+	req.SetQuestion(name, qtype)
+	w := &testResponseWriter{}
+	responder.Handler().ServeDNS(w, req)
+	assert.Assert(t, w.msg != nil)
+	return w.msg
+}
+
+// Test A records come healthiest first
+func TestResponderA(t *testing.T) {
+	t.Parallel()
+
+	msg := queryResponder(t, "seeds.example.com.", mdns.TypeA)
+	assert.Equal(t, mdns.RcodeSuccess, msg.Rcode)
+	assert.Equal(t, 2, len(msg.Answer))
+	assert.Equal(t, "10.0.0.2", msg.Answer[0].(*mdns.A).A.String())
+	assert.Equal(t, "10.0.0.1", msg.Answer[1].(*mdns.A).A.String())
+}
+
+// Test TXT records carry the dnsaddr multiaddrs
+func TestResponderTXT(t *testing.T) {
+	t.Parallel()
+
+	msg := queryResponder(t, "_dnsaddr.seeds.example.com.", mdns.TypeTXT)
+	assert.Equal(t, 2, len(msg.Answer))
+	assert.DeepEqual(t, []string{"dnsaddr=/ip4/10.0.0.2/tcp/45050/p2p/QmNew"}, msg.Answer[0].(*mdns.TXT).Txt)
+}
+
+// Test other domains are refused
+func TestResponderRefused(t *testing.T) {
+	t.Parallel()
+
+	msg := queryResponder(t, "example.org.", mdns.TypeA)
+	assert.Equal(t, mdns.RcodeRefused, msg.Rcode)
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+)
+
+type rpcTestResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	ID json.RawMessage `json:"id"`
+}
+
+// Creates an API server with JSON-RPC enabled
+func newTestRPC(t *testing.T, backend api.Backend) *api.Server {
+	var wg sync.WaitGroup
+
+	config := cfg.DefaultAPIConfig()
+	config.RPCUser = "user"
+	config.RPCPassword = "secret"
+	server, err := api.NewServer(context.Background(), func() {}, &wg, backend, "127.0.0.1:0", config)
+	assert.NilError(t, err)
+	return server
+}
+
+// Posts a JSON-RPC body with the given credentials
+func rpcPost(server *api.Server, user string, password string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.SetBasicAuth(user, password)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// Calls a method expecting a single response
+func rpcCall(t *testing.T, server *api.Server, method string, params string) *rpcTestResponse {
+	body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+	rec := rpcPost(server, "user", "secret", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	response := &rpcTestResponse{}
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), response))
+	assert.Equal(t, "2.0", response.JSONRPC)
+	return response
+}
+
+func TestRPCAuth(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRPC(t, &fakeBackend{})
+	body := `{"jsonrpc":"2.0","id":1,"method":"getblockcount"}`
+	rec := rpcPost(server, "user", "wrong", body)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Assert(t, rec.Header().Get("WWW-Authenticate") != "")
+
+	// Without credentials configured there's no endpoint
+	rec = rpcPost(newTestAPI(t, &fakeBackend{}), "user", "secret", body)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRPCMethods(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRPC(t, newChainBackend(t, 3))
+
+	response := rpcCall(t, server, "getblockcount", `[]`)
+	assert.Assert(t, response.Error == nil)
+	assert.Equal(t, "42", string(response.Result))
+
+	response = rpcCall(t, server, "getblockhash", `[1]`)
+	assert.Equal(t, `"block1"`, string(response.Result))
+
+	response = rpcCall(t, server, "getblock", `["block2"]`)
+	var block struct {
+		Height        uint64   `json:"height"`
+		Confirmations uint64   `json:"confirmations"`
+		Transactions  []string `json:"tx"`
+	}
+	assert.NilError(t, json.Unmarshal(response.Result, &block))
+	assert.Equal(t, uint64(2), block.Height)
+	assert.Equal(t, uint64(41), block.Confirmations)
+	assert.DeepEqual(t, []string{"tx2"}, block.Transactions)
+
+	response = rpcCall(t, server, "gettransaction", `["nope"]`)
+	assert.Equal(t, -5, response.Error.Code)
+
+	response = rpcCall(t, server, "getbalance", `["`+addressN+`"]`)
+	assert.Equal(t, "0.00000000", string(response.Result))
+
+	response = rpcCall(t, server, "validateaddress", `["nope"]`)
+	assert.Equal(t, `{"isvalid":false}`, string(response.Result))
+
+	response = rpcCall(t, server, "getpeerinfo", `[]`)
+	assert.Assert(t, strings.Contains(string(response.Result), `"addr":"10.0.0.1:45050"`), string(response.Result))
+
+	response = rpcCall(t, server, "getblockhash", `["one"]`)
+	assert.Equal(t, -32602, response.Error.Code)
+
+	response = rpcCall(t, server, "nope", `[]`)
+	assert.Equal(t, -32601, response.Error.Code)
+}
+
+func TestRPCSendRawTransaction(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRPC(t, &fakeBackend{})
+	transaction := newSignedTransaction(t)
+	data, err := proto.Marshal(transaction)
+	assert.NilError(t, err)
+	raw := `["` + hex.EncodeToString(data) + `"]`
+
+	response := rpcCall(t, server, "sendrawtransaction", raw)
+	assert.Equal(t, `"`+transaction.Hash+`"`, string(response.Result))
+
+	response = rpcCall(t, server, "sendrawtransaction", raw)
+	assert.Equal(t, -27, response.Error.Code)
+
+	response = rpcCall(t, server, "sendrawtransaction", `["zz"]`)
+	assert.Equal(t, -22, response.Error.Code)
+}
+
+func TestRPCBatch(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRPC(t, &fakeBackend{})
+	body := `[
+		{"jsonrpc":"2.0","id":1,"method":"getblockcount"},
+		{"jsonrpc":"2.0","method":"getblockcount"},
+		{"jsonrpc":"2.0","id":"b","method":"getbestblockhash"}
+	]`
+	rec := rpcPost(server, "user", "secret", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var responses []rpcTestResponse
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+	assert.Equal(t, 2, len(responses))
+	assert.Equal(t, `"b"`, string(responses[1].ID))
+	assert.Equal(t, `"abc"`, string(responses[1].Result))
+
+	// Notifications get nothing back
+	rec = rpcPost(server, "user", "secret", `{"jsonrpc":"2.0","method":"getblockcount"}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = rpcPost(server, "user", "secret", `{nope`)
+	response := &rpcTestResponse{}
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), response))
+	assert.Equal(t, -32700, response.Error.Code)
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/api"
+	cfg "github.com/Friends-Of-Noso/NosoGo/config"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+// Writes a self signed certificate and its key, returning their paths
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NilError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	assert.NilError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NilError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+// Test that no certificate means plain HTTP
+func TestTLSConfigDisabled(t *testing.T) {
+	t.Parallel()
+
+	config, err := utils.NewTLSConfig("", "", "")
+	assert.NilError(t, err)
+	assert.Assert(t, config == nil)
+
+	_, err = utils.NewTLSConfig("cert.pem", "", "")
+	assert.ErrorContains(t, err, "both the certificate and the key")
+}
+
+// Test that the certificate is served and the client CA is loaded
+func TestTLSConfigClientCA(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	config, err := utils.NewTLSConfig(certFile, keyFile, certFile)
+	assert.NilError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, config.ClientAuth)
+	assert.Assert(t, config.ClientCAs != nil)
+
+	certificate, err := config.GetCertificate(&tls.ClientHelloInfo{})
+	assert.NilError(t, err)
+	assert.Assert(t, certificate != nil)
+
+	_, err = utils.NewTLSConfig(certFile, keyFile, keyFile)
+	assert.ErrorContains(t, err, "no certificates found")
+}
+
+// Test that admin endpoints refuse requests without a client certificate
+func TestDNSAdminRequiresClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	config := cfg.DefaultDNSConfig()
+	config.TLSCertFile = certFile
+	config.TLSKeyFile = keyFile
+	config.TLSClientCAFile = certFile
+	server := newTestDNSWithConfig(t, config)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/peers/QmNode", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// Without a client CA the admin endpoints don't exist
+	rec = httptest.NewRecorder()
+	newTestDNS(t).Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// Test that API admin operations need a client certificate once a client CA is set
+func TestAPIAdminRequiresClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	config := cfg.DefaultAPIConfig()
+	config.Tokens = []string{"admin:" + cTestAdminToken}
+	config.TLSCertFile = certFile
+	config.TLSKeyFile = keyFile
+	config.TLSClientCAFile = certFile
+	server, err := api.NewServer(context.Background(), func() {}, &wg, &fakeBackend{}, "127.0.0.1:0", config)
+	assert.NilError(t, err)
+
+	assert.Equal(t, http.StatusForbidden, apiAuthRequest(server, http.MethodGet, api.APIBans, cTestAdminToken).Code)
+	assert.Equal(t, http.StatusOK, apiAuthRequest(server, http.MethodGet, api.APIBlocksStatus, "").Code)
+
+	// A verified certificate still needs the admin token
+	req := httptest.NewRequest(http.MethodGet, api.Route(api.APIBans), nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req.Header.Set("Authorization", "Bearer "+cTestAdminToken)
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -1,10 +1,16 @@
 package tests
 
 import (
+	"encoding/hex"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"google.golang.org/protobuf/proto"
 	"gotest.tools/v3/assert"
 
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
 	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
 )
 
@@ -22,3 +28,54 @@ func TestTransactionsSetHash(t *testing.T) {
 	want := "T4E6F736F2997166BEB866E8F02A81AA7F0D5C70038257C31ADC5988DE2D59D16A240D45D"
 	assert.Equal(t, want, transaction.Hash)
 }
+
+// Creates a pending transaction signed by a fresh key
+func newSignedTransaction(t *testing.T) *pb.Transaction {
+	privateKey, err := btcec.NewPrivateKey()
+	assert.NilError(t, err)
+	publicKey := hex.EncodeToString(privateKey.PubKey().SerializeUncompressed())
+
+	transaction := &pb.Transaction{
+		Type:      "TRFR",
+		Timestamp: time.Now().Unix(),
+		Sender:    legacy.GetAddressFromPublicKey(publicKey, 0),
+		Receiver:  addressN,
+		Amount:    100,
+	}
+	assert.NilError(t, transaction.Sign(hex.EncodeToString(privateKey.Serialize()), publicKey))
+	return transaction
+}
+
+// Test that pending transactions are checked field by field
+func TestTransactionsValidatePending(t *testing.T) {
+	t.Parallel()
+
+	transaction := newSignedTransaction(t)
+	now := transaction.Timestamp
+	assert.NilError(t, transaction.ValidatePending(now))
+
+	tests := []struct {
+		name   string
+		change func(*pb.Transaction)
+		field  string
+	}{
+		{"confirmed", func(tx *pb.Transaction) { tx.BlockHeight = 1 }, "block_height"},
+		{"coinbase", func(tx *pb.Transaction) { tx.Type = pb.TransactionTypeCoinbase }, "type"},
+		{"no amount", func(tx *pb.Transaction) { tx.Amount = 0 }, "amount"},
+		{"fee", func(tx *pb.Transaction) { tx.Fee = 10000 }, "fee"},
+		{"stale", func(tx *pb.Transaction) { tx.Timestamp -= 3600 }, "timestamp"},
+		{"bad receiver", func(tx *pb.Transaction) { tx.Receiver = "nope" }, "receiver"},
+		{"to itself", func(tx *pb.Transaction) { tx.Receiver = tx.Sender }, "receiver"},
+		{"other key", func(tx *pb.Transaction) { tx.PubKey = newSignedTransaction(t).PubKey }, "pub_key"},
+		{"tampered", func(tx *pb.Transaction) { tx.Amount++ }, "verify"},
+		{"wrong hash", func(tx *pb.Transaction) { tx.Hash = "T1234" }, "hash"},
+	}
+	for _, test := range tests {
+		tx := proto.Clone(transaction).(*pb.Transaction)
+		test.change(tx)
+
+		var transactionErr *pb.TransactionError
+		assert.Assert(t, errors.As(tx.ValidatePending(now), &transactionErr), test.name)
+		assert.Equal(t, test.field, transactionErr.Field, test.name)
+	}
+}
@@ -0,0 +1,262 @@
+package tests
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"gotest.tools/v3/assert"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	pb "github.com/Friends-Of-Noso/NosoGo/protobuf"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+	"github.com/Friends-Of-Noso/NosoGo/wallet"
+)
+
+const cTestPassphrase = "correct horse battery staple"
+
+// Test the generated private key is the one of the public key
+func TestGenerateNewAddressKeys(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, address := legacy.GenerateNewAddress()
+	keyBytes, err := hex.DecodeString(privateKey)
+	assert.NilError(t, err)
+	assert.Equal(t, btcec.PrivKeyBytesLen, len(keyBytes))
+
+	privKey, _ := btcec.PrivKeyFromBytes(keyBytes)
+	assert.Equal(t, publicKey, hex.EncodeToString(privKey.PubKey().SerializeUncompressed()))
+	assert.Assert(t, legacy.IsAddressOfPublicKey(address, publicKey))
+}
+
+// Test a wallet only opens with its passphrase and keeps its accounts
+func TestWalletCreateOpen(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "wallet.json")
+	w, err := wallet.Create(file, cTestPassphrase)
+	assert.NilError(t, err)
+	account, err := w.NewAccount("savings")
+	assert.NilError(t, err)
+	assert.Assert(t, legacy.IsValidHashAddress(account.Address))
+
+	_, err = wallet.Create(file, cTestPassphrase)
+	assert.Assert(t, errors.Is(err, wallet.ErrWalletExists))
+
+	_, err = wallet.Open(file, "wrong")
+	assert.Assert(t, errors.Is(err, wallet.ErrWrongPassphrase))
+
+	w, err = wallet.Open(file, cTestPassphrase)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []wallet.Account{account}, w.Accounts())
+
+	// The key signs transactions the node accepts
+	privateKey, err := w.PrivateKey(account.Address)
+	assert.NilError(t, err)
+	transaction := &pb.Transaction{
+		Type:      pb.TransactionTypeTransfer,
+		Timestamp: time.Now().Unix(),
+		Sender:    account.Address,
+		Receiver:  addressN,
+		Amount:    100,
+	}
+	assert.NilError(t, transaction.Sign(privateKey, account.PublicKey))
+	assert.NilError(t, transaction.ValidatePending(transaction.Timestamp))
+}
+
+// Test the keys can only be used while the wallet is unlocked
+func TestWalletLock(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "wallet.json")
+	w, err := wallet.Create(file, cTestPassphrase)
+	assert.NilError(t, err)
+	account, err := w.NewAccount("")
+	assert.NilError(t, err)
+
+	w, err = wallet.Load(file)
+	assert.NilError(t, err)
+	assert.Assert(t, w.Locked())
+	assert.DeepEqual(t, []wallet.Account{account}, w.Accounts())
+	_, err = w.PrivateKey(account.Address)
+	assert.Assert(t, errors.Is(err, wallet.ErrLocked))
+	_, err = w.NewAccount("")
+	assert.Assert(t, errors.Is(err, wallet.ErrLocked))
+
+	assert.Assert(t, errors.Is(w.Unlock("wrong", 0), wallet.ErrWrongPassphrase))
+	assert.Assert(t, w.Locked())
+
+	assert.NilError(t, w.Unlock(cTestPassphrase, 0))
+	_, err = w.PrivateKey(account.Address)
+	assert.NilError(t, err)
+	w.Lock()
+	assert.Assert(t, w.Locked())
+
+	// Locks itself once the timeout is over
+	assert.NilError(t, w.Unlock(cTestPassphrase, 50*time.Millisecond))
+	assert.Assert(t, !w.Locked())
+	time.Sleep(200 * time.Millisecond)
+	assert.Assert(t, w.Locked())
+}
+
+// Test changing the passphrase keeps the keys and leaves backups on the previous one
+func TestWalletBackupExport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "wallet.json")
+	w, err := wallet.Create(file, cTestPassphrase)
+	assert.NilError(t, err)
+	_, err = w.NewAccount("first")
+	assert.NilError(t, err)
+	_, err = w.NewAccount("second")
+	assert.NilError(t, err)
+	keys, err := w.Export()
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(keys))
+
+	backup := filepath.Join(dir, "backup.json")
+	assert.NilError(t, w.Backup(backup))
+	assert.Assert(t, errors.Is(w.Backup(backup), os.ErrExist))
+	info, err := os.Stat(backup)
+	assert.NilError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	assert.NilError(t, w.ChangePassphrase("new passphrase"))
+	_, err = wallet.Open(file, cTestPassphrase)
+	assert.Assert(t, errors.Is(err, wallet.ErrWrongPassphrase))
+	w, err = wallet.Open(file, "new passphrase")
+	assert.NilError(t, err)
+	exported, err := w.Export()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, exported)
+
+	w, err = wallet.Open(backup, cTestPassphrase)
+	assert.NilError(t, err)
+	exported, err = w.Export()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, exported)
+
+	w.Lock()
+	_, err = w.Export()
+	assert.Assert(t, errors.Is(err, wallet.ErrLocked))
+	assert.Assert(t, errors.Is(w.ChangePassphrase("other"), wallet.ErrLocked))
+}
+
+// Test importing keys in both encodings
+func TestWalletImport(t *testing.T) {
+	t.Parallel()
+
+	w, err := wallet.Create(filepath.Join(t.TempDir(), "wallet.json"), cTestPassphrase)
+	assert.NilError(t, err)
+
+	priv, err := btcec.NewPrivateKey()
+	assert.NilError(t, err)
+
+	hexKey := hex.EncodeToString(priv.Serialize())
+	account, err := w.Import(hexKey, "", "hex")
+	assert.NilError(t, err)
+	assert.Equal(t, hex.EncodeToString(priv.PubKey().SerializeUncompressed()), account.PublicKey)
+	assert.Equal(t, legacy.GetAddressFromPublicKey(account.PublicKey, 0), account.Address)
+
+	_, err = w.Import(hexKey, "", "again")
+	assert.Assert(t, errors.Is(err, wallet.ErrAccountExists))
+
+	// The other address of the key can be asked for
+	other := legacy.GetAddressFromPublicKey(account.PublicKey, 1)
+	account, err = w.Import(hexKey, other, "")
+	assert.NilError(t, err)
+	assert.Equal(t, other, account.Address)
+
+	base64Key := base64.StdEncoding.EncodeToString(priv.Serialize())
+	account, err = w.Import(base64Key, "", "base64")
+	assert.NilError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(priv.PubKey().SerializeUncompressed()), account.PublicKey)
+
+	_, err = w.Import(base64Key, addressN, "")
+	assert.Assert(t, errors.Is(err, wallet.ErrInvalidKey))
+	_, err = w.Import("not a key", "", "")
+	assert.Assert(t, errors.Is(err, wallet.ErrInvalidKey))
+
+	privateKey, err := w.PrivateKey(account.Address)
+	assert.NilError(t, err)
+	assert.Equal(t, base64Key, privateKey)
+	assert.Equal(t, 3, len(w.Accounts()))
+}
+
+// Test the keys of a legacy wallet go in and out of a wallet
+func TestWalletLegacyImportExport(t *testing.T) {
+	t.Parallel()
+
+	w, err := wallet.Create(filepath.Join(t.TempDir(), "wallet.json"), cTestPassphrase)
+	assert.NilError(t, err)
+
+	entries := []legacy.WalletEntry{newLegacyWalletEntry(t), newLegacyWalletEntry(t)}
+	entries[0].Custom = "alias"
+	accounts, err := w.ImportLegacy(entries)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(accounts))
+	assert.Equal(t, "alias", accounts[0].Name)
+
+	// Already imported addresses are skipped
+	accounts, err = w.ImportLegacy(entries)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(accounts))
+
+	exported, err := w.ExportLegacy()
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(exported))
+	for i, entry := range exported {
+		assert.Equal(t, entries[i].Hash, entry.Hash)
+		assert.Equal(t, entries[i].PublicKey, entry.PublicKey)
+		assert.Equal(t, entries[i].PrivateKey, entry.PrivateKey)
+		assert.Equal(t, "", entry.Custom)
+	}
+
+	// A key that isn't the one of the address is refused
+	bad := newLegacyWalletEntry(t)
+	bad.PrivateKey = entries[0].PrivateKey
+	_, err = w.ImportLegacy([]legacy.WalletEntry{bad})
+	assert.Assert(t, errors.Is(err, wallet.ErrInvalidKey))
+}
+
+// Test amounts of coins are parsed and formatted without rounding
+func TestCoins(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value  string
+		amount uint64
+		err    bool
+	}{
+		{"1", utils.CoinDecimals, false},
+		{"12.5", 1_250_000_000, false},
+		{"0.00000001", 1, false},
+		{".5", 50_000_000, false},
+		{"3.", 300_000_000, false},
+		{"0.000000001", 0, true},
+		{"-1", 0, true},
+		{"+1", 0, true},
+		{"1e3", 0, true},
+		{".", 0, true},
+		{"", 0, true},
+		{"92233720368", 0, true},
+	}
+	for _, tt := range tests {
+		amount, err := utils.ParseCoins(tt.value)
+		if tt.err {
+			assert.Assert(t, errors.Is(err, utils.ErrInvalidAmount), tt.value)
+			continue
+		}
+		assert.NilError(t, err, tt.value)
+		assert.Equal(t, tt.amount, amount, tt.value)
+	}
+
+	assert.Equal(t, "12.50000000", utils.FormatCoins(1_250_000_000))
+	assert.Equal(t, "-0.00000001", utils.FormatCoins(-1))
+}
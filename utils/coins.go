@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CoinDecimals is the number of the smallest units in a coin
+	CoinDecimals = 100_000_000
+
+	cCoinDecimalPlaces = 8
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// FormatCoins formats an amount of the smallest units as coins, with all the decimals
+func FormatCoins(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%08d", sign, amount/CoinDecimals, amount%CoinDecimals)
+}
+
+// ParseCoins parses a positive amount of coins, up to 8 decimals, into the smallest units
+func ParseCoins(value string) (uint64, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(value), ".")
+	if whole == "" && fraction == "" || len(fraction) > cCoinDecimalPlaces {
+		return 0, fmt.Errorf("%w '%s'", ErrInvalidAmount, value)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	fraction += strings.Repeat("0", cCoinDecimalPlaces-len(fraction))
+
+	// Balances are signed, amounts must fit them
+	coins, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil || coins >= math.MaxInt64/CoinDecimals {
+		return 0, fmt.Errorf("%w '%s'", ErrInvalidAmount, value)
+	}
+	units, err := strconv.ParseUint(fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w '%s'", ErrInvalidAmount, value)
+	}
+	return coins*CoinDecimals + units, nil
+}
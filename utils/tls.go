@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/Friends-Of-Noso/NosoGo/logger"
+)
+
+const (
+	cCertificateCheckInterval = 10 * time.Second
+)
+
+// CertificateReloader serves a certificate and reloads it when the files change
+type CertificateReloader struct {
+	mu          sync.Mutex
+	certFile    string
+	keyFile     string
+	certificate *tls.Certificate
+	modTime     time.Time
+	lastCheck   time.Time
+}
+
+// NewCertificateReloader loads the certificate and key pair
+func NewCertificateReloader(certFile string, keyFile string) (*CertificateReloader, error) {
+	cr := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	modTime, err := cr.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := cr.load(modTime); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// GetCertificate is meant to be used as tls.Config.GetCertificate
+func (cr *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	if time.Since(cr.lastCheck) >= cCertificateCheckInterval {
+		cr.lastCheck = time.Now()
+		if modTime, err := cr.latestModTime(); err != nil {
+			log.Error("could not check certificate files", err)
+		} else if modTime.After(cr.modTime) {
+			// Keep serving the old certificate if the new one is broken
+			if err := cr.load(modTime); err != nil {
+				log.Error("could not reload certificate", err)
+			} else {
+				log.Infof("reloaded certificate '%s'", cr.certFile)
+			}
+		}
+	}
+
+	return cr.certificate, nil
+}
+
+func (cr *CertificateReloader) load(modTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load certificate '%s': %w", cr.certFile, err)
+	}
+	cr.certificate = &certificate
+	cr.modTime = modTime
+	cr.lastCheck = time.Now()
+	return nil
+}
+
+func (cr *CertificateReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{cr.certFile, cr.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// NewTLSConfig builds a server TLS config, nil if no certificate is given.
+// With a client CA, client certificates are verified when presented.
+func NewTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both the certificate and the key files are needed")
+	}
+
+	reloader, err := NewCertificateReloader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client CA '%s': %w", clientCAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA '%s'", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return config, nil
+}
+
+// RequireClientCertificate only lets through requests with a verified client certificate
+func RequireClientCertificate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			http.Error(w, "Client certificate required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,493 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/Friends-Of-Noso/NosoGo/legacy"
+	"github.com/Friends-Of-Noso/NosoGo/utils"
+)
+
+const (
+	cWalletVersion = 1
+
+	cKDFScrypt = "scrypt"
+	// Interactive login strength, about 100ms
+	cScryptN       = 1 << 15
+	cScryptR       = 8
+	cScryptP       = 1
+	cScryptKeySize = 32
+	cSaltSize      = 32
+
+	// Sealed to tell a wrong passphrase from a damaged key
+	cCheckValue = "nosogo-wallet"
+)
+
+var (
+	ErrWalletExists    = errors.New("wallet already exists")
+	ErrLocked          = errors.New("wallet is locked")
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+	ErrAccountExists   = errors.New("account already in the wallet")
+	ErrAccountNotFound = errors.New("account not in the wallet")
+	ErrInvalidKey      = errors.New("invalid private key")
+)
+
+// Account is an address of the wallet, without its private key
+type Account struct {
+	Address   string `json:"address"`
+	Name      string `json:"name,omitempty"`
+	PublicKey string `json:"public_key"`
+}
+
+// Key derivation parameters, kept in the file so they can be raised later
+type kdfParams struct {
+	Name string `json:"name"`
+	Salt string `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// An account with its private key sealed
+type sealedAccount struct {
+	Account
+	PrivateKey string `json:"private_key"`
+}
+
+type walletFile struct {
+	Version  int             `json:"version"`
+	KDF      kdfParams       `json:"kdf"`
+	Check    string          `json:"check"`
+	Accounts []sealedAccount `json:"accounts"`
+}
+
+// ExportedKey is an account with its private key in clear
+type ExportedKey struct {
+	Account
+	PrivateKey string `json:"private_key"`
+}
+
+// Wallet holds keypairs encrypted with a key derived from a passphrase.
+// The accounts can be read while locked, the keys need it unlocked.
+// Private keys are only decrypted when signing or exporting.
+type Wallet struct {
+	mu   sync.Mutex
+	path string
+	file walletFile
+	// Nil while locked
+	aead      cipher.AEAD
+	lockTimer *time.Timer
+}
+
+// Create writes a new empty wallet, it won't overwrite an existing one
+func Create(path string, passphrase string) (*Wallet, error) {
+	if utils.FileExists(path) {
+		return nil, ErrWalletExists
+	}
+
+	w := &Wallet{
+		path: path,
+		file: walletFile{
+			Version:  cWalletVersion,
+			Accounts: []sealedAccount{},
+		},
+	}
+	if err := w.rekey(passphrase); err != nil {
+		return nil, err
+	}
+
+	if err := w.save(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Load reads a wallet, locked
+func Load(path string) (*Wallet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Wallet{path: path}
+	if err := json.Unmarshal(data, &w.file); err != nil {
+		return nil, fmt.Errorf("could not decode wallet '%s': %w", path, err)
+	}
+	if w.file.Version != cWalletVersion {
+		return nil, fmt.Errorf("unsupported wallet version %d", w.file.Version)
+	}
+	return w, nil
+}
+
+// Open reads a wallet and unlocks it until locked again
+func Open(path string, passphrase string) (*Wallet, error) {
+	w, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Unlock(passphrase, 0); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Unlock derives the key of the wallet, failing with ErrWrongPassphrase when it
+// doesn't decrypt it. The wallet locks itself after the timeout, unless zero.
+func (w *Wallet) Unlock(passphrase string, timeout time.Duration) error {
+	aead, err := deriveKey(w.file.KDF, passphrase)
+	if err != nil {
+		return err
+	}
+	check, err := open(aead, w.file.Check, "")
+	if err != nil || string(check) != cCheckValue {
+		return ErrWrongPassphrase
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.aead = aead
+	if w.lockTimer != nil {
+		w.lockTimer.Stop()
+		w.lockTimer = nil
+	}
+	if timeout > 0 {
+		w.lockTimer = time.AfterFunc(timeout, w.Lock)
+	}
+	return nil
+}
+
+// Lock forgets the key of the wallet
+func (w *Wallet) Lock() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.aead = nil
+	if w.lockTimer != nil {
+		w.lockTimer.Stop()
+		w.lockTimer = nil
+	}
+}
+
+// Locked tells if the keys can't be used until unlocking the wallet
+func (w *Wallet) Locked() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.aead == nil
+}
+
+// ChangePassphrase encrypts every key again, under a new passphrase and salt
+func (w *Wallet) ChangePassphrase(passphrase string) error {
+	keys, err := w.Export()
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	previous, previousAEAD := w.file, w.aead
+	w.file.Accounts = make([]sealedAccount, 0, len(keys))
+	if err := w.rekey(passphrase); err != nil {
+		w.file, w.aead = previous, previousAEAD
+		return err
+	}
+	for _, key := range keys {
+		sealed, err := seal(w.aead, []byte(key.PrivateKey), key.Address)
+		if err != nil {
+			w.file, w.aead = previous, previousAEAD
+			return err
+		}
+		w.file.Accounts = append(w.file.Accounts, sealedAccount{Account: key.Account, PrivateKey: sealed})
+	}
+
+	if err := w.save(); err != nil {
+		w.file, w.aead = previous, previousAEAD
+		return err
+	}
+	return nil
+}
+
+// Export returns every account with its private key in clear
+func (w *Wallet) Export() ([]ExportedKey, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	keys := make([]ExportedKey, 0, len(w.file.Accounts))
+	for _, account := range w.file.Accounts {
+		privateKey, err := w.privateKey(account)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, ExportedKey{Account: account.Account, PrivateKey: privateKey})
+	}
+	return keys, nil
+}
+
+// Backup copies the wallet, still encrypted, to a file that must not exist yet
+func (w *Wallet) Backup(path string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data, err := json.MarshalIndent(w.file, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Accounts returns the accounts of the wallet, in the order they were added
+func (w *Wallet) Accounts() []Account {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	accounts := make([]Account, 0, len(w.file.Accounts))
+	for _, account := range w.file.Accounts {
+		accounts = append(accounts, account.Account)
+	}
+	return accounts
+}
+
+// Account returns the account of an address
+func (w *Wallet) Account(address string) (Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, account := range w.file.Accounts {
+		if account.Address == address {
+			return account.Account, nil
+		}
+	}
+	return Account{}, ErrAccountNotFound
+}
+
+// NewAccount generates a keypair and saves it
+func (w *Wallet) NewAccount(name string) (Account, error) {
+	publicKey, privateKey, address := legacy.GenerateNewAddress()
+	return w.add(Account{Address: address, Name: name, PublicKey: publicKey}, privateKey)
+}
+
+// Import saves a private key, hex or base64 encoded. Its public key gets the same
+// encoding. The address, when given, must be one of the key's, else the standard one is used.
+func (w *Wallet) Import(privateKey string, address string, name string) (Account, error) {
+	publicKey, err := publicKeyOf(privateKey)
+	if err != nil {
+		return Account{}, err
+	}
+
+	if address == "" {
+		address = legacy.GetAddressFromPublicKey(publicKey, 0)
+	} else if !legacy.IsAddressOfPublicKey(address, publicKey) {
+		return Account{}, fmt.Errorf("%w: it is not the key of '%s'", ErrInvalidKey, address)
+	}
+
+	return w.add(Account{Address: address, Name: name, PublicKey: publicKey}, privateKey)
+}
+
+// ImportLegacy saves the keys of a legacy Noso wallet, skipping the addresses
+// already in the wallet. Aliases become the names of the addresses.
+func (w *Wallet) ImportLegacy(entries []legacy.WalletEntry) ([]Account, error) {
+	imported := make([]Account, 0, len(entries))
+	for _, entry := range entries {
+		account, err := w.Import(entry.PrivateKey, entry.Hash, entry.Custom)
+		if errors.Is(err, ErrAccountExists) {
+			continue
+		}
+		if err != nil {
+			return imported, fmt.Errorf("could not import '%s': %w", entry.Hash, err)
+		}
+		imported = append(imported, account)
+	}
+	return imported, nil
+}
+
+// ExportLegacy returns the keys as legacy Noso wallet entries. Names aren't
+// exported as aliases, those are registered on chain, and balances are left
+// for the wallet to refresh.
+func (w *Wallet) ExportLegacy() ([]legacy.WalletEntry, error) {
+	keys, err := w.Export()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]legacy.WalletEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, legacy.WalletEntry{
+			Hash:       key.Address,
+			PublicKey:  key.PublicKey,
+			PrivateKey: key.PrivateKey,
+		})
+	}
+	return entries, nil
+}
+
+// PrivateKey decrypts the private key of an address
+func (w *Wallet) PrivateKey(address string) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, account := range w.file.Accounts {
+		if account.Address == address {
+			return w.privateKey(account)
+		}
+	}
+	return "", ErrAccountNotFound
+}
+
+func (w *Wallet) privateKey(account sealedAccount) (string, error) {
+	if w.aead == nil {
+		return "", ErrLocked
+	}
+	privateKey, err := open(w.aead, account.PrivateKey, account.Address)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt the key of '%s': %w", account.Address, err)
+	}
+	return string(privateKey), nil
+}
+
+func (w *Wallet) add(account Account, privateKey string) (Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.aead == nil {
+		return Account{}, ErrLocked
+	}
+	if slices.ContainsFunc(w.file.Accounts, func(a sealedAccount) bool { return a.Address == account.Address }) {
+		return Account{}, ErrAccountExists
+	}
+
+	// The address authenticates the sealed key, so keys can't be swapped around
+	sealed, err := seal(w.aead, []byte(privateKey), account.Address)
+	if err != nil {
+		return Account{}, err
+	}
+	w.file.Accounts = append(w.file.Accounts, sealedAccount{Account: account, PrivateKey: sealed})
+
+	if err := w.save(); err != nil {
+		w.file.Accounts = w.file.Accounts[:len(w.file.Accounts)-1]
+		return Account{}, err
+	}
+	return account, nil
+}
+
+// Derives a new key from the passphrase, with a fresh salt
+func (w *Wallet) rekey(passphrase string) error {
+	salt := make([]byte, cSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	w.file.KDF = kdfParams{
+		Name: cKDFScrypt,
+		Salt: base64.StdEncoding.EncodeToString(salt),
+		N:    cScryptN,
+		R:    cScryptR,
+		P:    cScryptP,
+	}
+
+	aead, err := deriveKey(w.file.KDF, passphrase)
+	if err != nil {
+		return err
+	}
+	check, err := seal(aead, []byte(cCheckValue), "")
+	if err != nil {
+		return err
+	}
+	w.file.Check = check
+	w.aead = aead
+	return nil
+}
+
+func deriveKey(params kdfParams, passphrase string) (cipher.AEAD, error) {
+	if params.Name != cKDFScrypt {
+		return nil, fmt.Errorf("unsupported key derivation '%s'", params.Name)
+	}
+	salt, err := base64.StdEncoding.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, cScryptKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypts with a random nonce, prepended to the base64 ciphertext
+func seal(aead cipher.AEAD, plaintext []byte, additionalData string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(additionalData))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func open(aead cipher.AEAD, sealed string, additionalData string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("sealed value too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(additionalData))
+}
+
+// Writes through a temporary file so a crash never leaves the wallet half written
+func (w *Wallet) save() error {
+	data, err := json.MarshalIndent(w.file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := utils.EnsureDir(filepath.Dir(w.path), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(w.path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(w.path+".tmp", w.path)
+}
+
+// Derives the uncompressed public key of a private key, in the key's encoding
+func publicKeyOf(privateKey string) (string, error) {
+	if keyBytes, err := hex.DecodeString(privateKey); err == nil {
+		if len(keyBytes) != btcec.PrivKeyBytesLen {
+			return "", ErrInvalidKey
+		}
+		privKey, _ := btcec.PrivKeyFromBytes(keyBytes)
+		return hex.EncodeToString(privKey.PubKey().SerializeUncompressed()), nil
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil || len(keyBytes) != btcec.PrivKeyBytesLen {
+		return "", ErrInvalidKey
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(keyBytes)
+	return base64.StdEncoding.EncodeToString(privKey.PubKey().SerializeUncompressed()), nil
+}